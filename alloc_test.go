@@ -4,9 +4,28 @@
 
 package uri
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestAllocs(t *testing.T) {
+	parser := NewParser(ParseOptions{Strict: true, RejectControl: true, Schemes: []string{"file"}})
+	buf := make([]byte, 0, 256)
+	httpsURI := MustParse("https://host/p?name=ferret#f")
+	escapedURI := MustParse("https://host/a%20b?name=ferret%23#f")
+	driveURI := MustParse("file:///C:/Users/me/a%20b.go")
+	uncURI := MustParse("file://shares/files/c%23/p.cs")
+	cleanFile := []byte("file:///home/user/x.go")
+	interner := NewInterner(nil, 0)
+	siblingPaths := make([]string, 64)
+	for i := range siblingPaths {
+		siblingPaths[i] = `C:\Users\me\project\file` + benchDecimal(i) + ".go"
+	}
+	dirtyInputs := make([]string, 64)
+	for i := range dirtyInputs {
+		dirtyInputs[i] = "https://Host/a%20b/file" + benchDecimal(i) + "?name=ferret"
+	}
 	tests := map[string]struct {
 		maxAllocs float64
 		fn        func(t *testing.T)
@@ -23,6 +42,18 @@ func TestAllocs(t *testing.T) {
 				}
 			},
 		},
+		"Parser clean canonical file is zero alloc": {
+			maxAllocs: 0,
+			fn: func(t *testing.T) {
+				u, err := parser.Parse("file:///home/user/x.go")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if u.String() != "file:///home/user/x.go" {
+					t.Fatalf("unexpected string %q", u.String())
+				}
+			},
+		},
 		"String is zero alloc": {
 			maxAllocs: 0,
 			fn: func(t *testing.T) {
@@ -50,8 +81,25 @@ func TestAllocs(t *testing.T) {
 				}
 			},
 		},
-		"Parse https opaque query is at most two allocs": {
-			maxAllocs: 2,
+		"Parse error is one alloc": {
+			maxAllocs: 1,
+			fn: func(t *testing.T) {
+				if _, err := Parse("f\xe4il:path"); err == nil {
+					t.Fatal("Parse() error = nil")
+				}
+			},
+		},
+		"Parse canonical non-file URI is zero alloc": {
+			maxAllocs: 0,
+			fn: func(t *testing.T) {
+				u, err := Parse("untitled:untitled-1")
+				if err != nil || u.String() != "untitled:untitled-1" {
+					t.Fatalf("Parse() = %q, %v", u.String(), err)
+				}
+			},
+		},
+		"Parse https opaque query is at most one alloc": {
+			maxAllocs: 1,
 			fn: func(t *testing.T) {
 				u, err := Parse("https://host/p?name=ferret#f")
 				if err != nil {
@@ -62,6 +110,105 @@ func TestAllocs(t *testing.T) {
 				}
 			},
 		},
+		"Parsed raw accessors and clean decoded accessors are zero alloc": {
+			maxAllocs: 0,
+			fn: func(t *testing.T) {
+				p := httpsURI.Parsed()
+				if p.Scheme() != "https" || p.Authority() != "host" || p.Path() != "/p" || p.RawQuery() != "name%3Dferret" {
+					t.Fatalf("Parsed() = %+v", p.Components())
+				}
+			},
+		},
+		"FilesFor sibling batch shares blocks": {
+			maxAllocs: 4,
+			fn: func(t *testing.T) {
+				n := 0
+				for u := range FilesFor(PlatformWindows, slices.Values(siblingPaths)) {
+					if u == "" {
+						t.Fatal("FilesFor() yielded empty URI")
+					}
+					n++
+				}
+				if n != len(siblingPaths) {
+					t.Fatalf("FilesFor() yielded %d URIs, want %d", n, len(siblingPaths))
+				}
+			},
+		},
+		"ParseAll dirty batch shares blocks": {
+			maxAllocs: 4,
+			fn: func(t *testing.T) {
+				n := 0
+				for u, err := range ParseAll(slices.Values(dirtyInputs)) {
+					if err != nil || u == "" {
+						t.Fatalf("ParseAll() = %q, %v", u, err)
+					}
+					n++
+				}
+				if n != len(dirtyInputs) {
+					t.Fatalf("ParseAll() yielded %d URIs, want %d", n, len(dirtyInputs))
+				}
+			},
+		},
+		"AppendText is zero alloc": {
+			maxAllocs: 0,
+			fn: func(t *testing.T) {
+				b, err := httpsURI.AppendText(buf[:0])
+				if err != nil || string(b) != "https://host/p?name%3Dferret#f" {
+					t.Fatalf("AppendText() = %q, %v", b, err)
+				}
+			},
+		},
+		"AppendNoEncoding escaped components is zero alloc": {
+			maxAllocs: 0,
+			fn: func(t *testing.T) {
+				if b := escapedURI.AppendNoEncoding(buf[:0]); string(b) != "https://host/a b?name=ferret%23#f" {
+					t.Fatalf("AppendNoEncoding() = %q", b)
+				}
+			},
+		},
+		"AppendFsPath windows drive is zero alloc": {
+			maxAllocs: 0,
+			fn: func(t *testing.T) {
+				if b := driveURI.AppendFsPath(buf[:0], PlatformWindows); string(b) != `c:\Users\me\a b.go` {
+					t.Fatalf("AppendFsPath() = %q", b)
+				}
+			},
+		},
+		"AppendFsPath unc is zero alloc": {
+			maxAllocs: 0,
+			fn: func(t *testing.T) {
+				if b := uncURI.AppendFsPath(buf[:0], PlatformPOSIX); string(b) != "//shares/files/c#/p.cs" {
+					t.Fatalf("AppendFsPath() = %q", b)
+				}
+			},
+		},
+		"ParseBytes clean canonical file is one alloc": {
+			maxAllocs: 1,
+			fn: func(t *testing.T) {
+				u, err := ParseBytes(cleanFile)
+				if err != nil || u != "file:///home/user/x.go" {
+					t.Fatalf("ParseBytes() = %q, %v", u, err)
+				}
+			},
+		},
+		"UnmarshalText clean canonical file is one alloc": {
+			maxAllocs: 1,
+			fn: func(t *testing.T) {
+				var u URI
+				if err := u.UnmarshalText(cleanFile); err != nil || u != "file:///home/user/x.go" {
+					t.Fatalf("UnmarshalText() = %q, %v", u, err)
+				}
+			},
+		},
+		"Interner repeated input is zero alloc": {
+			maxAllocs: 0,
+			fn: func(t *testing.T) {
+				u, err := interner.ParseBytes(cleanFile)
+				if err != nil || u != "file:///home/user/x.go" {
+					t.Fatalf("Interner.ParseBytes() = %q, %v", u, err)
+				}
+			},
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
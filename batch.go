@@ -0,0 +1,240 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
+// batchBlockSize is the size of the blocks that batch conversions carve their
+// results from.
+const batchBlockSize = 4 << 10
+
+// ParseAll returns an iterator over Parse applied to each string of inputs.
+//
+// Canonical inputs yield without allocating, as with Parse. Other inputs are
+// decoded into one scratch buffer reused across inputs, and their results are
+// carved from shared blocks as FilesFor does.
+func ParseAll(inputs iter.Seq[string]) iter.Seq2[URI, error] {
+	return func(yield func(URI, error) bool) {
+		var b parseBatch
+		for s := range inputs {
+			if !yield(b.parse(s)) {
+				return
+			}
+		}
+	}
+}
+
+// FilesFor returns an iterator over FileFor(platform, path) for each path of
+// paths.
+//
+// It is meant for converting many paths at once, such as a burst of watched
+// file changes. Windows separators are rewritten in one reused scratch
+// buffer, a path in the same directory as the previous one reuses that
+// directory's encoded form, and results are carved from shared blocks of a
+// few KiB instead of allocated one by one. Retaining any result keeps its
+// block reachable; clone the few URIs kept from a large batch with
+// strings.Clone if that matters.
+func FilesFor(platform Platform, paths iter.Seq[string]) iter.Seq[URI] {
+	return func(yield func(URI) bool) {
+		b := fileBatch{platform: platform}
+		for path := range paths {
+			if !yield(b.file(path)) {
+				return
+			}
+		}
+	}
+}
+
+// FsPathsFor returns an iterator over FsPathFor(u, platform, false) for each
+// URI of uris, carving results that need decoding from shared blocks as
+// FilesFor does.
+func FsPathsFor(platform Platform, uris iter.Seq[URI]) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		var a batchArena
+		for u := range uris {
+			path, ok := fsPathFast(u, platform, false)
+			if !ok {
+				start := a.grow(len(u))
+				a.buf = u.Parsed().AppendFsPath(a.buf, platform)
+				path = a.take(start)
+			}
+			if !yield(path) {
+				return
+			}
+		}
+	}
+}
+
+// batchArena hands out strings that share append-only blocks. Bytes are never
+// modified once a string covering them has been returned.
+type batchArena struct {
+	buf []byte
+}
+
+// grow makes room for n more bytes, starting a new block when the current one
+// is too full, and returns the offset the next string starts at.
+func (a *batchArena) grow(n int) int {
+	if cap(a.buf)-len(a.buf) < n {
+		a.buf = make([]byte, 0, max(batchBlockSize, n))
+	}
+	return len(a.buf)
+}
+
+// take returns the bytes appended since start as a string.
+func (a *batchArena) take(start int) string {
+	return bytesString(a.buf[start:])
+}
+
+// parseBatch is the ParseAll state carried from one input to the next.
+type parseBatch struct {
+	arena batchArena
+	// scratch holds the decoded components of the current input. They are
+	// dead once its result is formatted into arena.
+	scratch []byte
+}
+
+// parse is Parse with the general path decoding into b.scratch and every
+// escaped result formatted into b.arena.
+func (b *parseBatch) parse(s string) (URI, error) {
+	if u, ok := parseCanonicalFileFast(s); ok {
+		return u, nil
+	}
+	raw := splitRaw(s)
+	a := &b.arena
+	escapes, ok, err := canonicalEscapes(s, &raw, false)
+	switch {
+	case err != nil:
+		return "", locateError(err, &raw)
+	case ok && escapes == 0:
+		return URI(s), nil
+	case ok:
+		start := a.grow(len(s) + 2*escapes)
+		a.buf = appendEscapedRawParts(a.buf, s, &raw)
+		return URI(a.take(start)), nil
+	}
+
+	b.scratch = b.scratch[:0]
+	c := Components{
+		Scheme:    schemeFix(raw.scheme, false),
+		Authority: b.decode(raw.authority),
+		Path:      b.decode(raw.path),
+		Query:     b.decode(raw.query),
+		Fragment:  b.decode(raw.fragment),
+	}
+	c.Path = referenceResolution(c.Scheme, c.Path)
+	if err := validateComponents(&c, false, "parse", s); err != nil {
+		return "", locateError(err, &raw)
+	}
+	// Lowercase the host and drive letter here, so that the formatter finds
+	// nothing to lowercase and does not allocate.
+	c.Authority = b.lowerHost(c.Authority)
+	if hasUpperDrive(c.Path) {
+		start := len(b.scratch)
+		b.scratch = append(b.scratch, c.Path...)
+		drive := start + strings.IndexByte(c.Path, ':') - 1
+		b.scratch[drive] = toLowerASCII(b.scratch[drive])
+		c.Path = bytesString(b.scratch[start:])
+	}
+	start := a.grow(len("file:///") + 3*len(s))
+	a.buf = appendComponents(a.buf, &c, false)
+	return URI(a.take(start)), nil
+}
+
+// decode appends the decoded form of component to b.scratch and returns it.
+func (b *parseBatch) decode(component string) string {
+	if strings.IndexByte(component, '%') < 0 {
+		return component
+	}
+	start := len(b.scratch)
+	b.scratch = appendPercentDecode(b.scratch, component)
+	return bytesString(b.scratch[start:])
+}
+
+// lowerHost returns authority with ASCII letters after any userinfo
+// lowercased in b.scratch. Non-ASCII hosts are left to the formatter.
+func (b *parseBatch) lowerHost(authority string) string {
+	host := strings.IndexByte(authority, '@') + 1
+	upper := false
+	for i := host; i < len(authority); i++ {
+		if authority[i] >= utf8.RuneSelf {
+			return authority
+		}
+		upper = upper || isUpperASCII(authority[i])
+	}
+	if !upper {
+		return authority
+	}
+	start := len(b.scratch)
+	b.scratch = append(b.scratch, authority...)
+	for i := start + host; i < len(b.scratch); i++ {
+		b.scratch[i] = toLowerASCII(b.scratch[i])
+	}
+	return bytesString(b.scratch[start:])
+}
+
+// fileBatch is the FilesFor state carried from one path to the next.
+type fileBatch struct {
+	platform Platform
+	arena    batchArena
+	// scratch holds the current path with Windows separators rewritten.
+	scratch []byte
+	// dir is the previous path up to and including its last '/', and dirURI
+	// is the URI prefix it formatted to.
+	dir    []byte
+	dirURI string
+	// dirSlash reports whether the previous path had a '/' prepended.
+	dirSlash bool
+}
+
+func (b *fileBatch) file(path string) URI {
+	if b.platform == PlatformWindows && strings.IndexByte(path, '\\') >= 0 {
+		b.scratch = append(b.scratch[:0], path...)
+		for i, c := range b.scratch {
+			if c == '\\' {
+				b.scratch[i] = '/'
+			}
+		}
+		path = bytesString(b.scratch)
+	}
+	if strings.HasPrefix(path, "//") {
+		// UNC paths are rare within one batch and carry an authority;
+		// FileFor already formats them in a single allocation.
+		return FileFor(PlatformPOSIX, path)
+	}
+
+	slash := path == "" || path[0] != '/'
+	dirEnd := strings.LastIndexByte(path, '/') + 1
+	a := &b.arena
+	start := a.grow(len("file:///") + 3*len(path))
+	shared := slash == b.dirSlash && b.dirURI != "" && string(b.dir) == path[:dirEnd]
+	if shared {
+		a.buf = append(a.buf, b.dirURI...)
+	} else {
+		a.buf = append(a.buf, "file://"...)
+		if slash {
+			a.buf = append(a.buf, '/')
+		}
+		a.buf = appendComponentFast(a.buf, path[:dirEnd], true, false)
+	}
+	dirLen := len(a.buf) - start
+	a.buf = appendComponentFast(a.buf, path[dirEnd:], true, false)
+	// Lowercase the drive letter the way formatPathDrive does; it sits right
+	// after "file:///" and is never escaped.
+	if slash && len(path) >= 2 && path[1] == ':' && isUpperASCII(path[0]) ||
+		!slash && len(path) >= 3 && path[2] == ':' && isUpperASCII(path[1]) {
+		drive := start + len("file:///")
+		a.buf[drive] = toLowerASCII(a.buf[drive])
+	}
+	if !shared {
+		b.dir = append(b.dir[:0], path[:dirEnd]...)
+		b.dirURI = bytesString(a.buf[start : start+dirLen])
+		b.dirSlash = slash
+	}
+	return URI(a.take(start))
+}
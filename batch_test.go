@@ -0,0 +1,142 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFilesFor(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		platform Platform
+		paths    []string
+	}{
+		"success: posix siblings and directory changes": {
+			platform: PlatformPOSIX,
+			paths: []string{
+				"/home/user/project/a.go",
+				"/home/user/project/b.go",
+				"/home/user/project/sub/c.go",
+				"/home/user/project/d.go",
+				"/Users/me/go/pkg/mod/example.com/mod@v1.2.3/file.go",
+				"/Users/me/go/pkg/mod/example.com/mod@v1.2.3/other file.go",
+				"/home/user/Zürich/c#/plugin.json",
+				"/",
+				"",
+				"relative/x.go",
+				"relative/y.go",
+				"/relative/y.go",
+				"//server/share/x.go",
+				"//server/share/y.go",
+			},
+		},
+		"success: windows drives and separators": {
+			platform: PlatformWindows,
+			paths: []string{
+				`C:\Users\me\project\main.go`,
+				`C:\Users\me\project\util.go`,
+				`c:\Users\me\project\util.go`,
+				`C:/Users/me/project/x.go`,
+				`C:`,
+				`C:\`,
+				`D:\a b\c.go`,
+				`\\server\share\x.go`,
+				`\\server\share\y.go`,
+				`/C:/x.go`,
+				`\relative\x.go`,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			i := 0
+			for got := range FilesFor(tt.platform, slices.Values(tt.paths)) {
+				if want := FileFor(tt.platform, tt.paths[i]); got != want {
+					t.Fatalf("FilesFor() item %d (%q) = %q, want %q", i, tt.paths[i], got, want)
+				}
+				i++
+			}
+			if i != len(tt.paths) {
+				t.Fatalf("FilesFor() yielded %d items, want %d", i, len(tt.paths))
+			}
+		})
+	}
+}
+
+func TestFsPathsFor(t *testing.T) {
+	t.Parallel()
+
+	uris := []URI{
+		MustParse("file:///home/user/x.go"),
+		MustParse("file:///C:/Users/me/a%20b.go"),
+		MustParse("file://server/share/c%23/p.cs"),
+		MustParse("untitled:untitled-1"),
+	}
+	for _, platform := range []Platform{PlatformPOSIX, PlatformWindows} {
+		i := 0
+		for got := range FsPathsFor(platform, slices.Values(uris)) {
+			if want := FsPathFor(uris[i], platform, false); got != want {
+				t.Fatalf("FsPathsFor(%v) item %d = %q, want %q", platform, i, got, want)
+			}
+			i++
+		}
+		if i != len(uris) {
+			t.Fatalf("FsPathsFor(%v) yielded %d items, want %d", platform, i, len(uris))
+		}
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"file:///home/user/x.go",
+		"https://host/p?name=ferret#f",
+		"https://Host/a%20b?q=%2541#%23",
+		"C:\\Users\\me",
+		"file:///c%3A/a%20b.go",
+		"file:////shares/files/p.cs",
+		"untitled:untitled-1",
+		"HTTP://example.com",
+		"mailto:a%40example.com",
+		"/home/user/Zürich/c%23.go",
+	}
+	i := 0
+	for got, err := range ParseAll(slices.Values(inputs)) {
+		want, wantErr := Parse(inputs[i])
+		if got != want || (err == nil) != (wantErr == nil) || err != nil && err.Error() != wantErr.Error() {
+			t.Fatalf("ParseAll() item %d (%q) = %q, %v, want %q, %v", i, inputs[i], got, err, want, wantErr)
+		}
+		i++
+	}
+	if i != len(inputs) {
+		t.Fatalf("ParseAll() yielded %d items, want %d", i, len(inputs))
+	}
+}
+
+func TestParseAllStops(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{"file:///home/user/x.go", "https://host/p?name=ferret#f", "file:////shares/files/p.cs", "untitled:untitled-1"}
+	var got []URI
+	for u, err := range ParseAll(slices.Values(inputs)) {
+		if err != nil {
+			if !errors.Is(err, ErrPathAuthority) {
+				t.Fatalf("ParseAll() error = %v, want %v", err, ErrPathAuthority)
+			}
+			break
+		}
+		got = append(got, u)
+	}
+	want := []URI{MustParse(inputs[0]), MustParse(inputs[1])}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ParseAll() = %q, want %q", got, want)
+	}
+}
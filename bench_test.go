@@ -6,6 +6,7 @@ package uri
 
 import (
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
 
@@ -45,6 +46,64 @@ func BenchmarkParse(b *testing.B) {
 	}
 }
 
+func BenchmarkParseBytes(b *testing.B) {
+	tests := loadBenchmarkCorpus(b)
+	for _, tt := range tests {
+		text := []byte(tt.text)
+		b.Run(tt.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var got URI
+			for b.Loop() {
+				u, err := ParseBytes(text)
+				if err != nil {
+					b.Fatal(err)
+				}
+				got = u
+			}
+			benchmarkURISink = got
+		})
+	}
+}
+
+func BenchmarkParseBytesStringConversion(b *testing.B) {
+	tests := loadBenchmarkCorpus(b)
+	for _, tt := range tests {
+		text := []byte(tt.text)
+		b.Run(tt.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var got URI
+			for b.Loop() {
+				u, err := Parse(string(text))
+				if err != nil {
+					b.Fatal(err)
+				}
+				got = u
+			}
+			benchmarkURISink = got
+		})
+	}
+}
+
+func BenchmarkInternerParseBytes(b *testing.B) {
+	tests := loadBenchmarkCorpus(b)
+	for _, tt := range tests {
+		text := []byte(tt.text)
+		b.Run(tt.name, func(b *testing.B) {
+			in := NewInterner(nil, 0)
+			b.ReportAllocs()
+			var got URI
+			for b.Loop() {
+				u, err := in.ParseBytes(text)
+				if err != nil {
+					b.Fatal(err)
+				}
+				got = u
+			}
+			benchmarkURISink = got
+		})
+	}
+}
+
 func BenchmarkParseNetURLBaseline(b *testing.B) {
 	tests := loadBenchmarkCorpus(b)
 	for _, tt := range tests {
@@ -98,6 +157,102 @@ func BenchmarkFsPath(b *testing.B) {
 	}
 }
 
+func BenchmarkAppend(b *testing.B) {
+	tests := map[string]func(u URI, dst []byte) []byte{
+		"AppendText": func(u URI, dst []byte) []byte {
+			dst, _ = u.AppendText(dst)
+			return dst
+		},
+		"AppendNoEncoding": func(u URI, dst []byte) []byte {
+			return u.AppendNoEncoding(dst)
+		},
+		"AppendFsPath-windows": func(u URI, dst []byte) []byte {
+			return u.AppendFsPath(dst, PlatformWindows)
+		},
+	}
+	u := MustParse("file:///C:/Users/me/go/pkg/mod/example.com/mod@v1.2.3/file%20name.go")
+	for name, fn := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			dst := make([]byte, 0, 256)
+			for b.Loop() {
+				dst = fn(u, dst[:0])
+			}
+			benchmarkIntSink = len(dst)
+		})
+	}
+}
+
+func BenchmarkFilesFor(b *testing.B) {
+	tests := map[string]struct {
+		platform Platform
+		dir      string
+	}{
+		"posix-gomodcache": {
+			platform: PlatformPOSIX,
+			dir:      "/Users/me/go/pkg/mod/example.com/mod@v1.2.3/internal/pkg",
+		},
+		"windows-drive": {
+			platform: PlatformWindows,
+			dir:      `C:\Users\me\project\internal\pkg`,
+		},
+	}
+	for name, tt := range tests {
+		sep := "/"
+		if tt.platform == PlatformWindows {
+			sep = `\`
+		}
+		paths := make([]string, 1_000)
+		for i := range paths {
+			paths[i] = tt.dir + benchDecimal(i/100) + sep + "file" + benchDecimal(i) + ".go"
+		}
+		uris := make([]URI, len(paths))
+		for i, path := range paths {
+			uris[i] = FileFor(tt.platform, path)
+		}
+		b.Run(name+"/FileFor", func(b *testing.B) {
+			b.ReportAllocs()
+			var got URI
+			for b.Loop() {
+				for _, path := range paths {
+					got = FileFor(tt.platform, path)
+				}
+			}
+			benchmarkURISink = got
+		})
+		b.Run(name+"/FilesFor", func(b *testing.B) {
+			b.ReportAllocs()
+			var got URI
+			for b.Loop() {
+				for u := range FilesFor(tt.platform, slices.Values(paths)) {
+					got = u
+				}
+			}
+			benchmarkURISink = got
+		})
+		b.Run(name+"/FsPathFor", func(b *testing.B) {
+			b.ReportAllocs()
+			var got string
+			for b.Loop() {
+				for _, u := range uris {
+					got = FsPathFor(u, tt.platform, false)
+				}
+			}
+			benchmarkStringSink = got
+		})
+		b.Run(name+"/FsPathsFor", func(b *testing.B) {
+			b.ReportAllocs()
+			var got string
+			for b.Loop() {
+				for path := range FsPathsFor(tt.platform, slices.Values(uris)) {
+					got = path
+				}
+			}
+			benchmarkStringSink = got
+		})
+	}
+}
+
 func BenchmarkString(b *testing.B) {
 	u := MustParse("file:///home/user/project/main.go")
 	b.ReportAllocs()
@@ -163,6 +318,35 @@ func BenchmarkComponents(b *testing.B) {
 	}
 }
 
+func BenchmarkAccessors(b *testing.B) {
+	tests := map[string]func(u URI) int{
+		"URI": func(u URI) int {
+			if u.Scheme() != schemeFile {
+				return 0
+			}
+			return len(u.Authority()) + len(u.Path()) + len(FsPathFor(u, PlatformWindows, false))
+		},
+		"Parsed": func(u URI) int {
+			p := u.Parsed()
+			if p.Scheme() != schemeFile {
+				return 0
+			}
+			return len(p.Authority()) + len(p.Path()) + len(p.FsPathFor(PlatformWindows, false))
+		},
+	}
+	u := MustParse("file://server/share/go/pkg/mod/example.com/mod@v1.2.3/file.go")
+	for name, fn := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			var got int
+			for b.Loop() {
+				got += fn(u)
+			}
+			benchmarkIntSink = got
+		})
+	}
+}
+
 func BenchmarkPercentDecodeMalformedRun(b *testing.B) {
 	tests := map[string]string{
 		"invalid-100":         strings.Repeat("%FF", 100),
@@ -182,6 +366,47 @@ func BenchmarkPercentDecodeMalformedRun(b *testing.B) {
 	}
 }
 
+func BenchmarkPassPrefix(b *testing.B) {
+	tests := map[string]func(s string) int{
+		"swar": func(s string) int {
+			return passPrefix(s, true)
+		},
+		"scalar": func(s string) int {
+			return scalarPassPrefix(s, true)
+		},
+	}
+	path := "/home/user/src/github.com/example/project/internal/protocol/generated/server/handlers_gen.go"
+	for name, fn := range tests {
+		b.Run(name, func(b *testing.B) {
+			var got int
+			for b.Loop() {
+				got += fn(path)
+			}
+			benchmarkIntSink = got
+		})
+	}
+}
+
+func BenchmarkParseLimitsReject(b *testing.B) {
+	limits := Limits{MaxLength: 1 << 20, MaxPercentTriplets: 1_000}
+	tests := map[string]string{
+		"too-long-1MiB":       "file:///" + strings.Repeat("a", 1<<20),
+		"too-many-triplets":   "foo:" + strings.Repeat("%FF", 10_000),
+		"within-limits-clean": "file:///home/user/project/x.go",
+	}
+	p := NewParser(ParseOptions{Limits: limits})
+	for name, input := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			var got URI
+			for b.Loop() {
+				got, _ = p.Parse(input)
+			}
+			benchmarkURISink = got
+		})
+	}
+}
+
 func BenchmarkMapKeyURI(b *testing.B) {
 	uris := make([]URI, 10_000)
 	for i := range uris {
@@ -224,7 +449,7 @@ func BenchmarkMapKeyNetURLStringBaseline(b *testing.B) {
 	benchmarkIntSink = got
 }
 
-func loadBenchmarkCorpus(b *testing.B) []benchmarkCase {
+func loadBenchmarkCorpus(b testing.TB) []benchmarkCase {
 	b.Helper()
 	var tests []benchmarkCase
 	for lineNumber, line := range strings.Split(benchmarkCorpusTSV, "\n") {
@@ -0,0 +1,110 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+var defaultParser = NewParser(ParseOptions{})
+
+// ParseBytes is like Parse but reads b without first converting it to a
+// string. Already canonical input is copied exactly once into the result; the
+// result never aliases b.
+func ParseBytes(b []byte) (URI, error) {
+	return defaultParser.ParseBytes(b)
+}
+
+// ParseBytes is like Parse but reads b without first converting it to a
+// string. The result and any returned error never alias b.
+func (p *Parser) ParseBytes(b []byte) (URI, error) {
+	s := bytesString(b)
+	u, err := p.Parse(s)
+	if err != nil {
+		var e *Error
+		if errors.As(err, &e) && e.Input == s {
+			e.Input = string(b)
+		}
+		return "", err
+	}
+	if aliases(string(u), s) {
+		return URI(strings.Clone(string(u))), nil
+	}
+	return u, nil
+}
+
+// aliases reports whether sub points into the memory of s.
+func aliases(sub, s string) bool {
+	if sub == "" || s == "" {
+		return false
+	}
+	base := uintptr(unsafe.Pointer(unsafe.StringData(s)))
+	p := uintptr(unsafe.Pointer(unsafe.StringData(sub)))
+	return p >= base && p < base+uintptr(len(s))
+}
+
+// DefaultInternerEntries is the number of inputs an Interner caches when
+// NewInterner is given no positive limit.
+const DefaultInternerEntries = 1 << 16
+
+// Interner parses byte slices into URIs and reuses the URI of previously seen
+// input, so repeated URIs in a stream of messages parse without allocating.
+// An Interner is safe for concurrent use.
+type Interner struct {
+	parser     *Parser
+	maxEntries int
+
+	mu sync.RWMutex
+	m  map[string]URI
+}
+
+// NewInterner returns an Interner that parses with p, or with Parse semantics
+// when p is nil. Once maxEntries inputs are cached, further new inputs are
+// parsed but not cached; maxEntries <= 0 means DefaultInternerEntries.
+func NewInterner(p *Parser, maxEntries int) *Interner {
+	if p == nil {
+		p = defaultParser
+	}
+	if maxEntries <= 0 {
+		maxEntries = DefaultInternerEntries
+	}
+	return &Interner{parser: p, maxEntries: maxEntries, m: make(map[string]URI)}
+}
+
+// ParseBytes returns the cached URI for b, parsing and caching it on first
+// use. Failed parses are not cached.
+func (in *Interner) ParseBytes(b []byte) (URI, error) {
+	in.mu.RLock()
+	u, ok := in.m[string(b)]
+	in.mu.RUnlock()
+	if ok {
+		return u, nil
+	}
+
+	u, err := in.parser.ParseBytes(b)
+	if err != nil {
+		return "", err
+	}
+	in.mu.Lock()
+	if len(in.m) < in.maxEntries {
+		if string(u) == string(b) {
+			in.m[string(u)] = u // canonical input: the key shares u's memory
+		} else {
+			in.m[string(b)] = u
+		}
+	}
+	in.mu.Unlock()
+	return u, nil
+}
+
+// Len returns the number of cached inputs.
+func (in *Interner) Len() int {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return len(in.m)
+}
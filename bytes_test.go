@@ -0,0 +1,107 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseBytes(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		wantErr error
+	}{
+		"success: canonical file":       {input: "file:///home/user/x.go"},
+		"success: canonical generic":    {input: "untitled:Untitled-1"},
+		"success: non-canonical https":  {input: "https://Host/p?name=ferret#f"},
+		"success: escaped unicode path": {input: "file:///a%C3%BC b.go"},
+		"error: invalid scheme":         {input: "fäil:path", wantErr: ErrInvalidScheme},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			want, wantErr := Parse(tt.input)
+			b := []byte(tt.input)
+			got, err := ParseBytes(b)
+			for i := range b {
+				b[i] = 'x'
+			}
+			if tt.wantErr != nil {
+				var uerr *Error
+				if !errors.Is(err, tt.wantErr) || !errors.As(err, &uerr) {
+					t.Fatalf("ParseBytes(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				if uerr.Input != tt.input || err.Error() != wantErr.Error() {
+					t.Fatalf("ParseBytes(%q) error = %q aliases input, want %q", tt.input, err.Error(), wantErr.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBytes(%q) error = %v", tt.input, err)
+			}
+			if got != want {
+				t.Fatalf("ParseBytes(%q) = %q after overwriting input, want %q", tt.input, got, want)
+			}
+		})
+	}
+}
+
+func TestInterner(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		maxEntries int
+		inputs     []string
+		wantLen    int
+	}{
+		"success: repeated inputs share one entry": {
+			inputs:  []string{"file:///a.go", "file:///a.go", "https://Host/p", "https://Host/p"},
+			wantLen: 2,
+		},
+		"success: max entries stops caching": {
+			maxEntries: 1,
+			inputs:     []string{"file:///a.go", "file:///b.go", "file:///c.go"},
+			wantLen:    1,
+		},
+		"success: zero max entries uses the default": {
+			maxEntries: 0,
+			inputs:     []string{"file:///a.go", "file:///b.go", "file:///c.go"},
+			wantLen:    3,
+		},
+		"success: failures are not cached": {
+			inputs:  []string{"fäil:path", "file:///a.go"},
+			wantLen: 1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			in := NewInterner(nil, tt.maxEntries)
+			for _, input := range tt.inputs {
+				want, wantErr := Parse(input)
+				b := []byte(input)
+				got, err := in.ParseBytes(b)
+				copy(b, "xxxxxxxx")
+				if (err != nil) != (wantErr != nil) || got != want {
+					t.Fatalf("Interner.ParseBytes(%q) = %q, %v, want %q, %v", input, got, err, want, wantErr)
+				}
+			}
+			if got := in.Len(); got != tt.wantLen {
+				t.Fatalf("Interner.Len() = %d, want %d", got, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestNewInternerDefaultLimit(t *testing.T) {
+	t.Parallel()
+
+	for _, maxEntries := range []int{0, -1} {
+		if got := NewInterner(nil, maxEntries).maxEntries; got != DefaultInternerEntries {
+			t.Fatalf("NewInterner(nil, %d) limit = %d, want %d", maxEntries, got, DefaultInternerEntries)
+		}
+	}
+}
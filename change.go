@@ -9,15 +9,19 @@ package uri
 // A nil field keeps the existing component. A non-nil pointer replaces that
 // component; a pointer to the empty string clears it, except Scheme follows
 // vscode-uri non-strict scheme fixing and becomes "file" when empty.
+//
+// Its JSON form is the argument of vscode-uri's with, which omits the
+// components it keeps.
 type Change struct {
-	Scheme    *string
-	Authority *string
-	Path      *string
-	Query     *string
-	Fragment  *string
+	Scheme    *string `json:"scheme,omitempty"`
+	Authority *string `json:"authority,omitempty"`
+	Path      *string `json:"path,omitempty"`
+	Query     *string `json:"query,omitempty"`
+	Fragment  *string `json:"fragment,omitempty"`
 }
 
-// With returns a new URI with selected decoded components changed.
+// With returns a new URI with selected decoded components changed. A changed
+// URI must satisfy the Authority policy registered for its scheme.
 func (u URI) With(change Change) (URI, error) {
 	c := u.Components()
 	before := c
@@ -39,6 +43,9 @@ func (u URI) With(change Change) (URI, error) {
 	if c == before {
 		return u, nil
 	}
+	if err := checkAuthority(&c, "with", u.String()); err != nil {
+		return "", err
+	}
 	return newURI(&c, false, "with", u.String())
 }
 
@@ -47,9 +54,17 @@ func (u URI) MarshalText() ([]byte, error) {
 	return []byte(u.String()), nil
 }
 
+// AppendText appends the canonical URI string to b, implementing
+// encoding.TextAppender.
+func (u URI) AppendText(b []byte) ([]byte, error) {
+	return append(b, u...), nil
+}
+
 // UnmarshalText parses text as a URI using non-strict vscode-uri semantics.
+// It enforces no Limits; decode untrusted input with a Parser, or with
+// WithJSONParser when using encoding/json/v2.
 func (u *URI) UnmarshalText(text []byte) error {
-	v, err := Parse(string(text))
+	v, err := defaultParser.ParseBytes(text)
 	if err != nil {
 		return err
 	}
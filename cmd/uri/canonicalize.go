@@ -0,0 +1,184 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.lsp.dev/uri"
+)
+
+func runCanonicalize(env *env, args []string) int {
+	w := bufio.NewWriter(env.stdout)
+	defer w.Flush()
+
+	if len(args) == 0 {
+		if err := canonicalize(w, env.stdin); err != nil {
+			fmt.Fprintf(env.stderr, "uri: %v\n", err)
+			return exitFailure
+		}
+		return exitOK
+	}
+	status := exitOK
+	for _, name := range args {
+		f, err := os.Open(name)
+		if err == nil {
+			err = canonicalize(w, f)
+			f.Close()
+		}
+		if err != nil {
+			fmt.Fprintf(env.stderr, "uri: %v\n", err)
+			status = exitFailure
+		}
+	}
+	return status
+}
+
+// canonicalize copies r to w line by line, rewriting the URIs that
+// canonicalizeLine recognizes.
+func canonicalize(w *bufio.Writer, r io.Reader) error {
+	br := bufio.NewReader(r)
+	var long, out []byte
+	for {
+		line, err := br.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			// A single long line, as in a JSON-RPC trace without newlines;
+			// collect the rest of it before scanning. ReadSlice's result is
+			// only valid until the next read, so copy it first.
+			long = append(long[:0], line...)
+			for errors.Is(err, bufio.ErrBufferFull) {
+				line, err = br.ReadSlice('\n')
+				long = append(long, line...)
+			}
+			line = long
+		}
+		out = canonicalizeLine(out[:0], line)
+		if _, werr := w.Write(out); werr != nil {
+			return werr
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// canonicalizeLine appends line to dst with every URI token replaced by
+// uri.Parse(token).String().
+//
+// The scanner is deliberately conservative, so that everything it does not
+// rewrite is copied byte for byte:
+//
+//   - a token starts with a scheme of at least two characters, so Windows
+//     drive paths such as C:/x are left alone, followed by ":/";
+//   - it ends before whitespace, control characters, and angle brackets, and
+//     a token opened by a quote or backtick ends at the matching one, so URIs
+//     inside JSON strings, Lua tables, and prose are found without their
+//     delimiters; an unquoted token ends at a double quote or backtick but
+//     keeps apostrophes, as in file:///home/me/it's.go;
+//   - trailing sentence punctuation and unbalanced closing brackets are not
+//     part of the token;
+//   - tokens containing a backslash, such as JSON escapes, and tokens that do
+//     not parse are copied unchanged.
+func canonicalizeLine(dst, line []byte) []byte {
+	done := 0
+	for i := 0; i+1 < len(line); i++ {
+		if line[i] != ':' || line[i+1] != '/' {
+			continue
+		}
+		start := schemeStart(line, i)
+		if start < 0 {
+			continue
+		}
+		end := tokenEnd(line, start, i+1)
+		token := line[start:end]
+		if bytes.IndexByte(token, '\\') < 0 {
+			if u, err := uri.Parse(string(token)); err == nil {
+				dst = append(dst, line[done:start]...)
+				dst = append(dst, u...)
+				done = end
+			}
+		}
+		i = end - 1
+	}
+	return append(dst, line[done:]...)
+}
+
+// schemeStart returns the offset of the scheme ending at line[colon], or -1
+// when the bytes before colon are not a scheme of at least two characters
+// starting at a word boundary.
+func schemeStart(line []byte, colon int) int {
+	start := colon
+	for start > 0 && isSchemeChar(line[start-1]) {
+		start--
+	}
+	if colon-start < 2 || !isLetter(line[start]) {
+		return -1
+	}
+	return start
+}
+
+// tokenEnd returns the end of the token starting at line[start] whose path
+// starts at line[i].
+func tokenEnd(line []byte, start, i int) int {
+	var quote byte
+	if start > 0 && isQuote(line[start-1]) {
+		quote = line[start-1]
+	}
+	depth := 0
+	end := i
+	for ; end < len(line); end++ {
+		c := line[end]
+		if c <= ' ' || c == 0x7f || c == '<' || c == '>' {
+			break
+		}
+		if quote != 0 && c == quote || quote == 0 && (c == '"' || c == '`') {
+			break
+		}
+		switch c {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				return trimPunctuation(line, i, end)
+			}
+			depth--
+		}
+	}
+	return trimPunctuation(line, i, end)
+}
+
+// trimPunctuation moves end back over trailing sentence punctuation, keeping
+// at least the byte at line[i].
+func trimPunctuation(line []byte, i, end int) int {
+	for end > i+1 {
+		switch line[end-1] {
+		case '.', ',', ';', ':', '!', '?':
+			end--
+			continue
+		}
+		break
+	}
+	return end
+}
+
+func isQuote(c byte) bool {
+	return c == '"' || c == '\'' || c == '`'
+}
+
+func isSchemeChar(c byte) bool {
+	return isLetter(c) || '0' <= c && c <= '9' || c == '+' || c == '-' || c == '.'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalizeLine(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		line string
+		want string
+	}{
+		"success: vscode trace": {
+			line: `Params: {"textDocument":{"uri":"file:///c%3A/src/a.go"}}`,
+			want: `Params: {"textDocument":{"uri":"file:///c%3A/src/a.go"}}`,
+		},
+		"success: neovim drive letter": {
+			line: `{ uri = "file:///C:/src/a.go" }`,
+			want: `{ uri = "file:///c%3A/src/a.go" }`,
+		},
+		"success: helix unescaped at sign": {
+			line: `"uri":"file:///home/me/node_modules/@types/a.d.ts"`,
+			want: `"uri":"file:///home/me/node_modules/%40types/a.d.ts"`,
+		},
+		"success: several tokens": {
+			line: "file:///A b/file:///C:/x\tuntitled:/Untitled-1 https://example.com/a b\n",
+			want: "file:///A b/file:///c%3A/x\tuntitled:/Untitled-1 https://example.com/a b\n",
+		},
+		"success: trailing punctuation and brackets": {
+			line: "see file:///C:/a.go, (file:///C:/b.go) and [file:///C:/c_(1).go].\r\n",
+			want: "see file:///c%3A/a.go, (file:///c%3A/b.go) and [file:///c%3A/c_%281%29.go].\r\n",
+		},
+		"success: non-ASCII path": {
+			line: `'file:///tmp/ü.txt'`,
+			want: `'file:///tmp/%C3%BC.txt'`,
+		},
+		"success: drive paths are not URIs": {
+			line: `C:/src/a.go c:\src\a.go`,
+			want: `C:/src/a.go c:\src\a.go`,
+		},
+		"success: apostrophe in an unquoted path": {
+			line: "open file:///C:/it's.go now",
+			want: "open file:///c%3A/it%27s.go now",
+		},
+		"success: single-quoted token ends at its quote": {
+			line: `uri = 'file:///C:/a.go', other = 'x'`,
+			want: `uri = 'file:///c%3A/a.go', other = 'x'`,
+		},
+		"success: double quote inside a backtick-quoted token": {
+			line: "`file:///C:/say\"hi\".go`",
+			want: "`file:///c%3A/say%22hi%22.go`",
+		},
+		"success: JSON escapes are left alone": {
+			line: `"uri":"file:///C:/a\u0020b" "file:///C:/a\\b"`,
+			want: `"uri":"file:///C:/a\u0020b" "file:///C:/a\\b"`,
+		},
+		"success: scheme must start at a word boundary": {
+			line: `1http://x/A%7e 9:/x`,
+			want: `1http://x/A%7e 9:/x`,
+		},
+		"success: text without URIs": {
+			line: "[Trace - 10:42:01 AM] Received response 'textDocument/hover - (12)' in 3ms.\n",
+			want: "[Trace - 10:42:01 AM] Received response 'textDocument/hover - (12)' in 3ms.\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := string(canonicalizeLine(nil, []byte(tt.line)))
+			if got != tt.want {
+				t.Fatalf("canonicalizeLine(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+			if again := string(canonicalizeLine(nil, []byte(got))); again != got {
+				t.Fatalf("canonicalizeLine(%q) = %q, want it unchanged", got, again)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeLongLine(t *testing.T) {
+	t.Parallel()
+
+	// Longer than the bufio.Reader buffer, with a URI straddling its end.
+	line := strings.Repeat("x", 4090) + ` "file:///C:/a.go" ` + strings.Repeat("y", 5000)
+	want := strings.Repeat("x", 4090) + ` "file:///c%3A/a.go" ` + strings.Repeat("y", 5000)
+	input := line + "\n" + line
+
+	var got strings.Builder
+	w := bufio.NewWriter(&got)
+	if err := canonicalize(w, strings.NewReader(input)); err != nil {
+		t.Fatalf("canonicalize() error = %v", err)
+	}
+	w.Flush()
+	if got.String() != want+"\n"+want {
+		t.Fatalf("canonicalize() did not rewrite the URI in a long line")
+	}
+}
+
+func TestRunCanonicalizeFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "trace.log")
+	if err := os.WriteFile(name, []byte(`{"uri":"file:///C:/a.go"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing.log")
+
+	var stdout, stderr strings.Builder
+	status := run([]string{"canonicalize", name, missing, name}, strings.NewReader(""), &stdout, &stderr)
+	if status != exitFailure {
+		t.Fatalf("run() = %d, want %d", status, exitFailure)
+	}
+	if got, want := stdout.String(), `{"uri":"file:///c%3A/a.go"}{"uri":"file:///c%3A/a.go"}`; got != want {
+		t.Fatalf("run() stdout = %q, want %q", got, want)
+	}
+	if got := stderr.String(); !strings.Contains(got, "missing.log") {
+		t.Fatalf("run() stderr = %q, want the missing file named", got)
+	}
+}
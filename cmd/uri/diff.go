@@ -0,0 +1,132 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"go.lsp.dev/uri"
+)
+
+// diffSide is the JSON form of one operand of diff.
+type diffSide struct {
+	Input string `json:"input"`
+	URI   string `json:"uri"`
+}
+
+// difference is one decoded component on which the operands of diff differ.
+type difference struct {
+	Component string `json:"component"`
+	A         string `json:"a"`
+	B         string `json:"b"`
+	Hint      string `json:"hint,omitempty"`
+}
+
+// diffResult is the JSON form of a diff result.
+type diffResult struct {
+	Equal       bool         `json:"equal"`
+	A           diffSide     `json:"a"`
+	B           diffSide     `json:"b"`
+	Differences []difference `json:"differences,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+func runDiff(env *env, args []string) int {
+	if len(args) == 0 {
+		if status := eachInput(env, nil, func(input string) bool {
+			args = append(args, input)
+			return true
+		}); status != exitOK {
+			return status
+		}
+	}
+	if len(args) != 2 {
+		fmt.Fprintln(env.stderr, "usage: uri diff [-json] [a b]")
+		return exitUsage
+	}
+	res := diffResult{A: diffSide{Input: args[0]}, B: diffSide{Input: args[1]}}
+	a, err := uri.Parse(args[0])
+	if err != nil {
+		res.Error = err.Error()
+		env.fail(res, err)
+		return exitFailure
+	}
+	res.A.URI = a.String()
+	b, err := uri.Parse(args[1])
+	if err != nil {
+		res.Error = err.Error()
+		env.fail(res, err)
+		return exitFailure
+	}
+	res.B.URI = b.String()
+	res.Equal = a == b
+	res.Differences = diffComponents(a.Components(), b.Components())
+
+	if env.json {
+		env.printJSON(res)
+	} else {
+		printDiffSide(env, "a", res.A)
+		printDiffSide(env, "b", res.B)
+		if res.Equal {
+			fmt.Fprintln(env.stdout, "equal")
+		}
+		for _, d := range res.Differences {
+			fmt.Fprintf(env.stdout, "%s: %q != %q", d.Component, d.A, d.B)
+			if d.Hint != "" {
+				fmt.Fprintf(env.stdout, " (%s)", d.Hint)
+			}
+			fmt.Fprintln(env.stdout)
+		}
+	}
+	if !res.Equal {
+		return exitFailure
+	}
+	return exitOK
+}
+
+// printDiffSide prints the canonical form of one operand, noting when the
+// input was not already canonical.
+func printDiffSide(env *env, name string, side diffSide) {
+	if side.Input == side.URI {
+		fmt.Fprintf(env.stdout, "%s: %s\n", name, side.URI)
+		return
+	}
+	fmt.Fprintf(env.stdout, "%s: %s (canonical form of %s)\n", name, side.URI, side.Input)
+}
+
+// diffComponents returns the decoded components on which a and b differ.
+func diffComponents(a, b uri.Components) []difference {
+	var diffs []difference
+	add := func(component, x, y string) {
+		if x != y {
+			diffs = append(diffs, difference{Component: component, A: x, B: y, Hint: diffHint(x, y)})
+		}
+	}
+	add("scheme", a.Scheme, b.Scheme)
+	add("authority", a.Authority, b.Authority)
+	add("path", a.Path, b.Path)
+	add("query", a.Query, b.Query)
+	add("fragment", a.Fragment, b.Fragment)
+	return diffs
+}
+
+// diffHint explains a common cause of two component values differing, or
+// returns "" when none applies.
+func diffHint(x, y string) string {
+	switch {
+	case x == "" || y == "":
+		return "present in only one"
+	case strings.EqualFold(x, y):
+		return "differs only in letter case"
+	case strings.TrimSuffix(x, "/") == strings.TrimSuffix(y, "/"):
+		return "differs only in a trailing slash"
+	case strings.EqualFold(strings.TrimSuffix(x, "/"), strings.TrimSuffix(y, "/")):
+		return "differs in letter case and a trailing slash"
+	case strings.ReplaceAll(x, "\\", "/") == strings.ReplaceAll(y, "\\", "/"):
+		return "differs only in path separators"
+	}
+	return ""
+}
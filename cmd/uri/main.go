@@ -0,0 +1,359 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command uri inspects and converts URIs the way vscode-uri does.
+//
+// Usage:
+//
+//	uri parse [-strict] [-json] [uri ...]
+//	uri file [-platform posix|windows] [-json] [path ...]
+//	uri fspath [-platform posix|windows] [-json] [uri ...]
+//	uri join [-json] uri segment ...
+//	uri resolve [-json] uri segment ...
+//	uri diff [-json] [a b]
+//	uri canonicalize [file ...]
+//
+// Commands that take a list of inputs read one per line from standard input
+// when none are given as arguments, and so does diff, which then expects
+// exactly two lines. With -json every result is printed as one
+// JSON object per line.
+//
+// Canonicalize copies text such as LSP trace logs to standard output with
+// every URI it recognizes rewritten to its canonical form, so that logs from
+// different clients can be compared with diff. URIs containing a backslash,
+// such as JSON-escaped ones, are copied unchanged rather than unescaped, so
+// decode such traces first to canonicalize every URI.
+//
+// The exit status is 0 on success, 1 if any input failed or diff found a
+// difference, and 2 for usage errors.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+
+	"go.lsp.dev/uri"
+)
+
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+const usage = `usage: uri <command> [flags] [args]
+
+Commands:
+  parse     print the components, canonical forms, and filesystem paths of URIs
+  file      convert filesystem paths to file URIs
+  fspath    convert URIs to filesystem paths
+  join      join path segments onto a URI
+  resolve   resolve path segments against a URI
+  diff      explain why two URIs are not canonically equal
+  canonicalize
+            rewrite the URIs in text to their canonical form
+
+Run "uri <command> -h" for the flags of a command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the process exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		if args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+			fmt.Fprint(stdout, usage)
+			return exitOK
+		}
+		fmt.Fprintf(stderr, "uri: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet("uri "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	env := &env{stdin: stdin, stdout: stdout, stderr: stderr}
+	cmd.flags(fs, env)
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if env.platformName != "" {
+		switch env.platformName {
+		case "posix":
+			env.platform = uri.PlatformPOSIX
+		case "windows":
+			env.platform = uri.PlatformWindows
+		default:
+			fmt.Fprintf(stderr, "uri %s: unknown platform %q, want posix or windows\n", args[0], env.platformName)
+			return exitUsage
+		}
+	}
+	return cmd.run(env, fs.Args())
+}
+
+// env carries the streams and shared flags of one command invocation.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	json         bool
+	strict       bool
+	platformName string
+	platform     uri.Platform
+}
+
+// command is one subcommand of uri.
+type command struct {
+	flags func(fs *flag.FlagSet, env *env)
+	run   func(env *env, args []string) int
+}
+
+var commands = map[string]command{
+	"parse": {
+		flags: func(fs *flag.FlagSet, env *env) {
+			jsonFlag(fs, env)
+			fs.BoolVar(&env.strict, "strict", false, "require a scheme, as ParseStrict does")
+		},
+		run: runParse,
+	},
+	"file": {
+		flags: platformFlags,
+		run:   runFile,
+	},
+	"fspath": {
+		flags: platformFlags,
+		run:   runFsPath,
+	},
+	"join": {
+		flags: jsonFlag,
+		run: func(env *env, args []string) int {
+			return runPath(env, "join", args, uri.JoinPath)
+		},
+	},
+	"resolve": {
+		flags: jsonFlag,
+		run: func(env *env, args []string) int {
+			return runPath(env, "resolve", args, uri.ResolvePath)
+		},
+	},
+	"diff": {
+		flags: jsonFlag,
+		run:   runDiff,
+	},
+	"canonicalize": {
+		flags: func(*flag.FlagSet, *env) {},
+		run:   runCanonicalize,
+	},
+}
+
+func jsonFlag(fs *flag.FlagSet, env *env) {
+	fs.BoolVar(&env.json, "json", false, "print one JSON object per result")
+}
+
+func platformFlags(fs *flag.FlagSet, env *env) {
+	jsonFlag(fs, env)
+	def := "posix"
+	if runtime.GOOS == "windows" {
+		def = "windows"
+	}
+	fs.StringVar(&env.platformName, "platform", def, "filesystem path semantics: posix or windows")
+}
+
+// parseResult is the JSON form of one parse result.
+type parseResult struct {
+	Input         string `json:"input"`
+	URI           string `json:"uri,omitempty"`
+	NoEncoding    string `json:"noEncoding,omitempty"`
+	Scheme        string `json:"scheme,omitempty"`
+	Authority     string `json:"authority,omitempty"`
+	Path          string `json:"path,omitempty"`
+	Query         string `json:"query,omitempty"`
+	Fragment      string `json:"fragment,omitempty"`
+	FsPathPOSIX   string `json:"fsPathPOSIX,omitempty"`
+	FsPathWindows string `json:"fsPathWindows,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+func runParse(env *env, args []string) int {
+	return eachInput(env, args, func(input string) bool {
+		parse := uri.Parse
+		if env.strict {
+			parse = uri.ParseStrict
+		}
+		u, err := parse(input)
+		if err != nil {
+			return env.fail(parseResult{Input: input, Error: err.Error()}, err)
+		}
+		p := u.Parsed()
+		res := parseResult{
+			Input:         input,
+			URI:           u.String(),
+			NoEncoding:    u.StringNoEncoding(),
+			Scheme:        p.Scheme(),
+			Authority:     p.Authority(),
+			Path:          p.Path(),
+			Query:         p.Query(),
+			Fragment:      p.Fragment(),
+			FsPathPOSIX:   p.FsPathFor(uri.PlatformPOSIX, false),
+			FsPathWindows: p.FsPathFor(uri.PlatformWindows, false),
+		}
+		if env.json {
+			return env.printJSON(res)
+		}
+		fmt.Fprintf(env.stdout, "input:          %s\n", res.Input)
+		fmt.Fprintf(env.stdout, "uri:            %s\n", res.URI)
+		fmt.Fprintf(env.stdout, "noEncoding:     %s\n", res.NoEncoding)
+		fmt.Fprintf(env.stdout, "scheme:         %s\n", res.Scheme)
+		fmt.Fprintf(env.stdout, "authority:      %s\n", res.Authority)
+		fmt.Fprintf(env.stdout, "path:           %s\n", res.Path)
+		fmt.Fprintf(env.stdout, "query:          %s\n", res.Query)
+		fmt.Fprintf(env.stdout, "fragment:       %s\n", res.Fragment)
+		fmt.Fprintf(env.stdout, "fsPath posix:   %s\n", res.FsPathPOSIX)
+		fmt.Fprintf(env.stdout, "fsPath windows: %s\n", res.FsPathWindows)
+		return true
+	})
+}
+
+// conversion is the JSON form of file, fspath, join, and resolve results.
+type conversion struct {
+	Input  string `json:"input"`
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func runFile(env *env, args []string) int {
+	return eachInput(env, args, func(input string) bool {
+		u, err := fileFor(env.platform, input)
+		if err != nil {
+			return env.fail(conversion{Input: input, Error: err.Error()}, err)
+		}
+		return env.printConversion(input, u.String())
+	})
+}
+
+// fileFor is uri.FileFor returning the error it panics with, as vscode-uri
+// throws, for paths such as "////" that leave "//" at the start of the path.
+func fileFor(platform uri.Platform, path string) (u uri.URI, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(error)
+			if !ok {
+				panic(r)
+			}
+			err = e
+		}
+	}()
+	return uri.FileFor(platform, path), nil
+}
+
+func runFsPath(env *env, args []string) int {
+	return eachInput(env, args, func(input string) bool {
+		u, err := uri.Parse(input)
+		if err != nil {
+			return env.fail(conversion{Input: input, Error: err.Error()}, err)
+		}
+		return env.printConversion(input, uri.FsPathFor(u, env.platform, false))
+	})
+}
+
+func runPath(env *env, name string, args []string, fn func(uri.URI, ...string) (uri.URI, error)) int {
+	if len(args) < 2 {
+		fmt.Fprintf(env.stderr, "usage: uri %s [-json] uri segment ...\n", name)
+		return exitUsage
+	}
+	u, err := uri.Parse(args[0])
+	if err == nil {
+		u, err = fn(u, args[1:]...)
+	}
+	input := strings.Join(args, " ")
+	if err != nil {
+		env.fail(conversion{Input: input, Error: err.Error()}, err)
+		return exitFailure
+	}
+	env.printConversion(input, u.String())
+	return exitOK
+}
+
+// eachInput calls fn for every argument, or for every non-empty line of
+// standard input when there are no arguments, and returns exitFailure if fn
+// reported a failure for any of them.
+func eachInput(env *env, args []string, fn func(input string) bool) int {
+	status := exitOK
+	if len(args) > 0 {
+		for _, arg := range args {
+			if !fn(arg) {
+				status = exitFailure
+			}
+		}
+		return status
+	}
+	sc := bufio.NewScanner(env.stdin)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if !fn(line) {
+			status = exitFailure
+		}
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintf(env.stderr, "uri: reading standard input: %v\n", err)
+		return exitFailure
+	}
+	return status
+}
+
+func (env *env) printConversion(input, output string) bool {
+	if env.json {
+		return env.printJSON(conversion{Input: input, Output: output})
+	}
+	fmt.Fprintln(env.stdout, output)
+	return true
+}
+
+// fail reports err for one input, as res in JSON mode or on standard error
+// otherwise, and returns false.
+func (env *env) fail(res any, err error) bool {
+	if env.json {
+		env.printJSON(res)
+		return false
+	}
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "uri: ") {
+		msg = "uri: " + msg
+	}
+	fmt.Fprintln(env.stderr, msg)
+	return false
+}
+
+// printJSON prints v as one line of JSON. URIs routinely contain '&', so HTML
+// escaping is off.
+func (env *env) printJSON(v any) bool {
+	enc := json.NewEncoder(env.stdout)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		fmt.Fprintf(env.stderr, "uri: %v\n", err)
+		return false
+	}
+	return true
+}
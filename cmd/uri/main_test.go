@@ -0,0 +1,169 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args       []string
+		stdin      string
+		wantStdout string
+		wantStderr string
+		wantStatus int
+	}{
+		"success: parse prints components and filesystem paths": {
+			args: []string{"parse", "file:///C:/My%20Docs/a.go?x#y"},
+			wantStdout: "input:          file:///C:/My%20Docs/a.go?x#y\n" +
+				"uri:            file:///c%3A/My%20Docs/a.go?x#y\n" +
+				"noEncoding:     file:///c:/My Docs/a.go?x#y\n" +
+				"scheme:         file\n" +
+				"authority:      \n" +
+				"path:           /c:/My Docs/a.go\n" +
+				"query:          x\n" +
+				"fragment:       y\n" +
+				"fsPath posix:   c:/My Docs/a.go\n" +
+				"fsPath windows: c:\\My Docs\\a.go\n",
+		},
+		"success: parse -json": {
+			args: []string{"parse", "-json", "https://example.com/a?b=1&c=2"},
+			wantStdout: `{"input":"https://example.com/a?b=1&c=2","uri":"https://example.com/a?b%3D1%26c%3D2",` +
+				`"noEncoding":"https://example.com/a?b=1&c=2","scheme":"https","authority":"example.com",` +
+				`"path":"/a","query":"b=1&c=2","fsPathPOSIX":"/a","fsPathWindows":"\\a"}` + "\n",
+		},
+		"success: file reads standard input": {
+			args:       []string{"file", "-platform", "posix"},
+			stdin:      "/tmp/a b.go\r\n\n/tmp/c#.go\n",
+			wantStdout: "file:///tmp/a%20b.go\nfile:///tmp/c%23.go\n",
+		},
+		"success: file on windows": {
+			args:       []string{"file", "-platform", "windows", `C:\src\main.go`, `\\server\share\x`},
+			wantStdout: "file:///c%3A/src/main.go\nfile://server/share/x\n",
+		},
+		"success: fspath": {
+			args:       []string{"fspath", "-platform", "windows", "-json", "file:///c%3A/src/main.go"},
+			wantStdout: `{"input":"file:///c%3A/src/main.go","output":"c:\\src\\main.go"}` + "\n",
+		},
+		"success: join": {
+			args:       []string{"join", "file:///a/b", "../c", "d"},
+			wantStdout: "file:///a/c/d\n",
+		},
+		"success: resolve": {
+			args:       []string{"resolve", "file:///a/b", "/c"},
+			wantStdout: "file:///c\n",
+		},
+		"success: diff of equivalent URIs": {
+			args:       []string{"diff", "file:///C:/a", "file:///c%3A/a"},
+			wantStdout: "a: file:///c%3A/a (canonical form of file:///C:/a)\nb: file:///c%3A/a\nequal\n",
+		},
+		"success: canonicalize reads standard input": {
+			args:       []string{"canonicalize"},
+			stdin:      "a file:///C:/a.go\nb\r\n",
+			wantStdout: "a file:///c%3A/a.go\nb\r\n",
+		},
+		"success: help": {
+			args:       []string{"help"},
+			wantStdout: usage,
+		},
+		"success: diff reads standard input": {
+			args:       []string{"diff", "-json"},
+			stdin:      "file:///C:/a\r\n\nfile:///c%3A/a\n",
+			wantStdout: `{"equal":true,"a":{"input":"file:///C:/a","uri":"file:///c%3A/a"},"b":{"input":"file:///c%3A/a","uri":"file:///c%3A/a"}}` + "\n",
+		},
+		"error: diff explains differences": {
+			args: []string{"diff", "https://example.com/A/?q", "https://example.com/a"},
+			wantStdout: "a: https://example.com/A/?q\n" +
+				"b: https://example.com/a\n" +
+				`path: "/A/" != "/a" (differs in letter case and a trailing slash)` + "\n" +
+				`query: "q" != "" (present in only one)` + "\n",
+			wantStatus: exitFailure,
+		},
+		"error: diff hints letter case": {
+			args: []string{"diff", "file:///src/Main.go", "file:///src/main.go"},
+			wantStdout: "a: file:///src/Main.go\n" +
+				"b: file:///src/main.go\n" +
+				`path: "/src/Main.go" != "/src/main.go" (differs only in letter case)` + "\n",
+			wantStatus: exitFailure,
+		},
+		"error: diff -json": {
+			args: []string{"diff", "-json", "file:///a", "untitled:///a"},
+			wantStdout: `{"equal":false,"a":{"input":"file:///a","uri":"file:///a"},` +
+				`"b":{"input":"untitled:///a","uri":"untitled:/a"},` +
+				`"differences":[{"component":"scheme","a":"file","b":"untitled"}]}` + "\n",
+			wantStatus: exitFailure,
+		},
+		"error: parse -strict reports failures and continues": {
+			args:       []string{"parse", "-strict", "-json", "foo", "a:b"},
+			wantStdout: `{"input":"foo","error":`,
+			wantStatus: exitFailure,
+		},
+		"error: file path that cannot be converted": {
+			args:       []string{"file", "-platform", "posix", "////"},
+			wantStderr: "uri: file \"//\": uri: path without authority cannot begin with two slashes\n",
+			wantStatus: exitFailure,
+		},
+		"error: no command": {
+			wantStderr: usage,
+			wantStatus: exitUsage,
+		},
+		"error: unknown command": {
+			args:       []string{"frob"},
+			wantStderr: "uri: unknown command \"frob\"\n\n" + usage,
+			wantStatus: exitUsage,
+		},
+		"error: unknown platform": {
+			args:       []string{"file", "-platform", "plan9", "/a"},
+			wantStderr: "uri file: unknown platform \"plan9\", want posix or windows\n",
+			wantStatus: exitUsage,
+		},
+		"error: join needs a segment": {
+			args:       []string{"join", "file:///a"},
+			wantStderr: "usage: uri join [-json] uri segment ...\n",
+			wantStatus: exitUsage,
+		},
+		"error: canonicalize has no JSON mode": {
+			args:       []string{"canonicalize", "-json"},
+			wantStatus: exitUsage,
+		},
+		"error: diff stops at an invalid second URI": {
+			args:       []string{"diff", "-json", "file:///a", "fäil:b"},
+			wantStdout: `{"equal":false,"a":{"input":"file:///a","uri":"file:///a"},"b":{"input":"fäil:b","uri":""},"error":`,
+			wantStatus: exitFailure,
+		},
+		"error: diff needs two URIs": {
+			args:       []string{"diff", "file:///a"},
+			wantStderr: "usage: uri diff [-json] [a b]\n",
+			wantStatus: exitUsage,
+		},
+		"error: diff needs two lines of standard input": {
+			args:       []string{"diff"},
+			stdin:      "file:///a\nfile:///b\nfile:///c\n",
+			wantStderr: "usage: uri diff [-json] [a b]\n",
+			wantStatus: exitUsage,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr strings.Builder
+			status := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if status != tt.wantStatus {
+				t.Fatalf("run(%q) = %d, want %d; stderr: %s", tt.args, status, tt.wantStatus, stderr.String())
+			}
+			if got := stdout.String(); !strings.HasPrefix(got, tt.wantStdout) || tt.wantStatus == exitOK && got != tt.wantStdout {
+				t.Fatalf("run(%q) stdout = %q, want %q", tt.args, got, tt.wantStdout)
+			}
+			if got := stderr.String(); tt.wantStderr != "" && got != tt.wantStderr {
+				t.Fatalf("run(%q) stderr = %q, want %q", tt.args, got, tt.wantStderr)
+			}
+		})
+	}
+}
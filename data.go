@@ -0,0 +1,350 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"encoding/base64"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	schemeData = "data"
+
+	dataDefaultMediaType = "text/plain"
+	dataBase64Token      = "base64"
+
+	// Header delimiters as they appear in the canonical encoded form.
+	dataEncodedComma     = "%2C"
+	dataEncodedSemicolon = "%3B"
+	dataEncodedEquals    = "%3D"
+)
+
+// DataURI is the decoded view of an RFC 2397 data: URI.
+//
+// The payload is kept in its percent-encoded URI form and decoded on demand by
+// Bytes or Reader, so parsing a multi-megabyte URI does not copy the payload.
+type DataURI struct {
+	mediaType string
+	params    map[string]string
+	base64    bool
+	payload   string
+}
+
+// ParseDataURI parses the media type, parameters, and payload of a data: URI.
+//
+// The header may be written raw or percent-encoded, so both
+// data:text/plain;base64,SGk= and its canonical form
+// data:text/plain%3Bbase64%2CSGk%3D are accepted. A fragment is not part of the
+// payload.
+func ParseDataURI(u URI) (DataURI, error) {
+	s := string(u)
+	raw := splitRaw(s)
+	if raw.scheme != schemeData {
+		return DataURI{}, uriError("data", s, ErrNotDataURI)
+	}
+	body := s[len(schemeData)+1 : raw.queryEnd]
+	header, payload, ok := cutDataComma(body)
+	if !ok {
+		return DataURI{}, uriError("data", s, ErrInvalidDataURI)
+	}
+
+	var d DataURI
+	parts := strings.Split(string(appendPercentDecoded(nil, header)), ";")
+	d.mediaType = strings.ToLower(strings.TrimSpace(parts[0]))
+	for i, part := range parts[1:] {
+		if i == len(parts)-2 && strings.EqualFold(part, dataBase64Token) {
+			d.base64 = true
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || name == "" {
+			return DataURI{}, uriError("data", s, ErrInvalidDataURI)
+		}
+		if d.params == nil {
+			d.params = make(map[string]string, len(parts)-1)
+		}
+		d.params[strings.ToLower(name)] = value
+	}
+	d.payload = payload
+	return d, nil
+}
+
+// MediaType returns the lowercased media type, defaulting to text/plain when
+// the URI omits it.
+func (d DataURI) MediaType() string {
+	if d.mediaType == "" {
+		return dataDefaultMediaType
+	}
+	return d.mediaType
+}
+
+// Params returns a copy of the media type parameters keyed by lowercased name.
+func (d DataURI) Params() map[string]string {
+	return maps.Clone(d.params)
+}
+
+// Param returns the value of the named media type parameter.
+func (d DataURI) Param(name string) string {
+	return d.params[strings.ToLower(name)]
+}
+
+// IsBase64 reports whether the payload is base64 encoded.
+func (d DataURI) IsBase64() bool {
+	return d.base64
+}
+
+// Bytes returns the decoded payload.
+func (d DataURI) Bytes() ([]byte, error) {
+	return io.ReadAll(d.Reader())
+}
+
+// Reader returns a reader that streams the decoded payload, percent-decoding
+// and, for base64 payloads, base64-decoding as it goes.
+func (d DataURI) Reader() io.Reader {
+	var r io.Reader = &percentReader{s: d.payload}
+	if d.base64 {
+		r = base64.NewDecoder(d.base64Encoding(), r)
+	}
+	return r
+}
+
+// base64Encoding returns the encoding of a base64 payload. Browsers accept
+// payloads whose final quantum lacks its '=' padding, so those decode with
+// RawStdEncoding.
+func (d DataURI) base64Encoding() *base64.Encoding {
+	n, last := 0, byte(0)
+	for s := d.payload; s != ""; n++ {
+		if c, ok := percentByte(s); ok {
+			last, s = c, s[3:]
+		} else {
+			last, s = s[0], s[1:]
+		}
+	}
+	if n%4 != 0 && last != '=' {
+		return base64.RawStdEncoding
+	}
+	return base64.StdEncoding
+}
+
+// NewDataURI returns the canonical data: URI for payload.
+//
+// The payload is base64 encoded when base64 is set and also whenever payload
+// is not valid UTF-8, because percent-encoded invalid UTF-8 does not survive
+// vscode-uri's graceful decoding and would not be canonical. IsBase64 on the
+// parsed result reports which encoding was used.
+//
+// mediaType must be empty or a type/subtype pair of RFC 2045 tokens, and
+// parameter names must be tokens. Parameter values must not contain ',' or
+// ';'. Parameters are written in sorted name order. NewDataURI panics on an
+// invalid header, like MustParse; use EncodeDataURI for a header from
+// untrusted input.
+func NewDataURI(mediaType string, params map[string]string, payload []byte, base64 bool) URI {
+	u, err := EncodeDataURI(mediaType, params, payload, base64)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// EncodeDataURI is like NewDataURI but reports an invalid header as an *Error
+// wrapping ErrInvalidDataURI.
+func EncodeDataURI(mediaType string, params map[string]string, payload []byte, base64 bool) (URI, error) {
+	if err := checkDataHeader(mediaType, params); err != nil {
+		return "", err
+	}
+	base64 = base64 || !utf8.Valid(payload)
+	b := make([]byte, 0, dataURILen(mediaType, params, len(payload), base64))
+	b = appendDataHeader(b, mediaType, params, base64)
+	if base64 {
+		w := &pathComponentWriter{b: b}
+		writeBase64(w, payload)
+		b = w.b
+	} else {
+		b = appendComponentFast(b, bytesString(payload), true, false)
+	}
+	return URI(bytesString(b)), nil
+}
+
+// NewDataURIReader is like EncodeDataURI but streams the payload from r.
+// Base64 payloads are encoded straight into the result without buffering r.
+func NewDataURIReader(mediaType string, params map[string]string, r io.Reader, base64 bool) (URI, error) {
+	if err := checkDataHeader(mediaType, params); err != nil {
+		return "", err
+	}
+	if !base64 {
+		payload, err := io.ReadAll(r)
+		if err != nil {
+			return "", err
+		}
+		return EncodeDataURI(mediaType, params, payload, false)
+	}
+	w := &pathComponentWriter{b: appendDataHeader(nil, mediaType, params, true)}
+	enc := newBase64Encoder(w)
+	if _, err := io.Copy(enc, r); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return URI(bytesString(w.b)), nil
+}
+
+func appendDataHeader(b []byte, mediaType string, params map[string]string, base64 bool) []byte {
+	var header strings.Builder
+	header.WriteString(mediaType)
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		header.WriteByte(';')
+		header.WriteString(name)
+		header.WriteByte('=')
+		header.WriteString(params[name])
+	}
+	if base64 {
+		header.WriteString(";" + dataBase64Token)
+	}
+	b = append(b, schemeData+":"...)
+	b = appendComponentFast(b, header.String(), true, false)
+	return append(b, dataEncodedComma...)
+}
+
+// checkDataHeader reports an *Error wrapping ErrInvalidDataURI when mediaType
+// or params cannot be written as an unambiguous data: header.
+func checkDataHeader(mediaType string, params map[string]string) error {
+	if mediaType != "" {
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok || !isDataToken(typ) || !isDataToken(subtype) {
+			return uriError("data", mediaType, ErrInvalidDataURI)
+		}
+	}
+	for name, value := range params {
+		if !isDataToken(name) {
+			return uriError("data", name, ErrInvalidDataURI)
+		}
+		if strings.ContainsAny(value, ",;") {
+			return uriError("data", value, ErrInvalidDataURI)
+		}
+	}
+	return nil
+}
+
+// isDataToken reports whether s is an RFC 2045 token: one or more printable
+// ASCII characters other than space and tspecials.
+func isDataToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c >= 0x7f || strings.IndexByte(`()<>@,;:\"/[]?=`, c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// dataURILen returns the capacity to reserve for a data: URI with an n-byte
+// payload. Header tokens are usually written unescaped, so only their
+// delimiters are counted in encoded form.
+func dataURILen(mediaType string, params map[string]string, n int, base64 bool) int {
+	size := len(schemeData+":") + len(mediaType) + len(dataEncodedComma)
+	for name, value := range params {
+		size += len(dataEncodedSemicolon) + len(name) + len(dataEncodedEquals) + len(value)
+	}
+	if !base64 {
+		return size + n
+	}
+	size += len(dataEncodedSemicolon) + len(dataBase64Token)
+	// Base64 output is escaped where it contains '+' or '=' padding, each
+	// growing by two bytes; reserve room for one in sixteen characters.
+	n = (n + 2) / 3 * 4 // padded base64 length
+	return size + n + n/16
+}
+
+func writeBase64(w io.Writer, payload []byte) {
+	enc := newBase64Encoder(w)
+	_, _ = enc.Write(payload)
+	_ = enc.Close()
+}
+
+func newBase64Encoder(w io.Writer) io.WriteCloser {
+	return base64.NewEncoder(base64.StdEncoding, w)
+}
+
+// cutDataComma splits a data: URI body at the first raw or percent-encoded
+// comma.
+func cutDataComma(body string) (header, payload string, ok bool) {
+	for i := 0; i < len(body); i++ {
+		switch {
+		case body[i] == ',':
+			return body[:i], body[i+1:], true
+		case body[i] == '%' && i+2 < len(body) && body[i+1] == '2' && (body[i+2] == 'C' || body[i+2] == 'c'):
+			return body[:i], body[i+3:], true
+		}
+	}
+	return "", "", false
+}
+
+// pathComponentWriter percent-encodes written bytes as a canonical path
+// component.
+type pathComponentWriter struct {
+	b []byte
+}
+
+func (w *pathComponentWriter) Write(p []byte) (int, error) {
+	w.b = appendComponentFast(w.b, bytesString(p), true, false)
+	return len(p), nil
+}
+
+// percentReader decodes percent triplets byte by byte. Unlike percentDecode it
+// does not apply vscode-uri's UTF-8 validation, because data: payloads are
+// octets rather than text.
+type percentReader struct {
+	s string
+}
+
+func (r *percentReader) Read(p []byte) (int, error) {
+	if r.s == "" {
+		return 0, io.EOF
+	}
+	n := 0
+	for n < len(p) && r.s != "" {
+		if c, ok := percentByte(r.s); ok {
+			p[n] = c
+			r.s = r.s[3:]
+		} else {
+			p[n] = r.s[0]
+			r.s = r.s[1:]
+		}
+		n++
+	}
+	return n, nil
+}
+
+func appendPercentDecoded(dst []byte, s string) []byte {
+	for s != "" {
+		if c, ok := percentByte(s); ok {
+			dst = append(dst, c)
+			s = s[3:]
+			continue
+		}
+		dst = append(dst, s[0])
+		s = s[1:]
+	}
+	return dst
+}
+
+func percentByte(s string) (byte, bool) {
+	if len(s) < 3 || s[0] != '%' {
+		return 0, false
+	}
+	hi, lo := hexDecodeTable[s[1]], hexDecodeTable[s[2]]
+	if hi|lo >= 0x80 {
+		return 0, false
+	}
+	return hi<<4 | lo, true
+}
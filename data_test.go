@@ -0,0 +1,283 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDataURI(t *testing.T) {
+	tests := map[string]struct {
+		input         string
+		wantMediaType string
+		wantParams    map[string]string
+		wantBase64    bool
+		wantBytes     []byte
+		wantError     error
+	}{
+		"success: raw base64 png": {
+			input:         "data:image/png;base64,iVBORw0KGgo=",
+			wantMediaType: "image/png",
+			wantBase64:    true,
+			wantBytes:     []byte("\x89PNG\r\n\x1a\n"),
+		},
+		"success: canonical base64 form": {
+			input:         "data:image/png%3Bbase64%2CiVBORw0KGgo%3D",
+			wantMediaType: "image/png",
+			wantBase64:    true,
+			wantBytes:     []byte("\x89PNG\r\n\x1a\n"),
+		},
+		"success: unpadded base64": {
+			input:         "data:text/plain;base64,SGk",
+			wantMediaType: "text/plain",
+			wantBase64:    true,
+			wantBytes:     []byte("Hi"),
+		},
+		"success: unpadded base64 of one byte": {
+			input:         "data:;base64,SA",
+			wantMediaType: "text/plain",
+			wantBase64:    true,
+			wantBytes:     []byte("H"),
+		},
+		"success: text with default media type": {
+			input:         "data:,Hello%2C%20World%21",
+			wantMediaType: "text/plain",
+			wantBytes:     []byte("Hello, World!"),
+		},
+		"success: parameters are lowercased by name": {
+			input:         "data:Text/HTML;Charset=utf-8,%3Cp%3E",
+			wantMediaType: "text/html",
+			wantParams:    map[string]string{"charset": "utf-8"},
+			wantBytes:     []byte("<p>"),
+		},
+		"success: query belongs to payload and fragment does not": {
+			input:         "data:,a?b#c",
+			wantMediaType: "text/plain",
+			wantBytes:     []byte("a?b"),
+		},
+		"success: canonical parse keeps invalid utf8 escapes literal": {
+			input:         "data:application/octet-stream,%FF%00",
+			wantMediaType: "application/octet-stream",
+			wantBytes:     []byte("%FF\x00"),
+		},
+		"error: not data scheme": {
+			input:     "file:///x.png",
+			wantError: ErrNotDataURI,
+		},
+		"error: missing comma": {
+			input:     "data:image/png;base64",
+			wantError: ErrInvalidDataURI,
+		},
+		"error: parameter without value": {
+			input:     "data:text/plain;charset,x",
+			wantError: ErrInvalidDataURI,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d, err := ParseDataURI(MustParse(tt.input))
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Fatalf("ParseDataURI() error = %v, want %v", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDataURI() error = %v", err)
+			}
+			if d.MediaType() != tt.wantMediaType {
+				t.Fatalf("MediaType() = %q, want %q", d.MediaType(), tt.wantMediaType)
+			}
+			if diff := cmp.Diff(tt.wantParams, d.Params()); diff != "" {
+				t.Fatalf("Params() mismatch (-want +got):\n%s", diff)
+			}
+			if d.IsBase64() != tt.wantBase64 {
+				t.Fatalf("IsBase64() = %t, want %t", d.IsBase64(), tt.wantBase64)
+			}
+			got, err := d.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.wantBytes) {
+				t.Fatalf("Bytes() = %q, want %q", got, tt.wantBytes)
+			}
+		})
+	}
+}
+
+func TestParseDataURICorruptBase64(t *testing.T) {
+	t.Parallel()
+
+	d, err := ParseDataURI(MustParse("data:;base64,not*base64"))
+	if err != nil {
+		t.Fatalf("ParseDataURI() error = %v", err)
+	}
+	if _, err := d.Bytes(); err == nil {
+		t.Fatal("Bytes() succeeded, want base64 error")
+	}
+}
+
+func TestNewDataURI(t *testing.T) {
+	tests := map[string]struct {
+		mediaType string
+		params    map[string]string
+		payload   []byte
+		base64    bool
+		want      string
+		wantB64   bool
+	}{
+		"success: base64 png": {
+			mediaType: "image/png",
+			payload:   []byte("\x89PNG\r\n\x1a\n"),
+			base64:    true,
+			want:      "data:image/png%3Bbase64%2CiVBORw0KGgo%3D",
+			wantB64:   true,
+		},
+		"success: sorted parameters": {
+			mediaType: "text/plain",
+			params:    map[string]string{"z": "1", "charset": "utf-8"},
+			payload:   []byte("a b?"),
+			want:      "data:text/plain%3Bcharset%3Dutf-8%3Bz%3D1%2Ca%20b%3F",
+		},
+		"success: empty media type and payload": {
+			want: "data:%2C",
+		},
+		"success: invalid utf8 text falls back to base64": {
+			mediaType: "application/octet-stream",
+			payload:   []byte{0xff, 0xfe},
+			want:      "data:application/octet-stream%3Bbase64%2C//4%3D",
+			wantB64:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u := NewDataURI(tt.mediaType, tt.params, tt.payload, tt.base64)
+			if u.String() != tt.want {
+				t.Fatalf("NewDataURI() = %q, want %q", u.String(), tt.want)
+			}
+			if reparsed := MustParse(u.String()); reparsed != u {
+				t.Fatalf("Parse(NewDataURI()) = %q, want canonical %q", reparsed.String(), u.String())
+			}
+			d, err := ParseDataURI(u)
+			if err != nil {
+				t.Fatalf("ParseDataURI() error = %v", err)
+			}
+			if d.IsBase64() != tt.wantB64 {
+				t.Fatalf("IsBase64() = %t, want %t", d.IsBase64(), tt.wantB64)
+			}
+			got, err := d.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.payload) {
+				t.Fatalf("Bytes() = %q, want %q", got, tt.payload)
+			}
+		})
+	}
+}
+
+func TestNewDataURIInvalidHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		mediaType string
+		params    map[string]string
+	}{
+		"error: media type without subtype": {
+			mediaType: "text",
+		},
+		"error: media type with parameter": {
+			mediaType: "text/plain;charset=utf-8",
+		},
+		"error: media type with comma": {
+			mediaType: "text/a,b",
+		},
+		"error: empty parameter name": {
+			mediaType: "text/plain",
+			params:    map[string]string{"": "x"},
+		},
+		"error: parameter name with equals": {
+			params: map[string]string{"a=b": "x"},
+		},
+		"error: parameter value with semicolon": {
+			params: map[string]string{"charset": "utf-8;base64"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if u, err := EncodeDataURI(tt.mediaType, tt.params, nil, false); !errors.Is(err, ErrInvalidDataURI) {
+				t.Fatalf("EncodeDataURI() = %q, %v, want ErrInvalidDataURI", u, err)
+			}
+			if u, err := NewDataURIReader(tt.mediaType, tt.params, strings.NewReader(""), true); !errors.Is(err, ErrInvalidDataURI) {
+				t.Fatalf("NewDataURIReader() = %q, %v, want ErrInvalidDataURI", u, err)
+			}
+			func() {
+				defer func() {
+					if err, _ := recover().(error); !errors.Is(err, ErrInvalidDataURI) {
+						t.Fatalf("NewDataURI() panic = %v, want ErrInvalidDataURI", err)
+					}
+				}()
+				NewDataURI(tt.mediaType, tt.params, nil, false)
+			}()
+		})
+	}
+}
+
+func TestNewDataURILargePayload(t *testing.T) {
+	t.Parallel()
+
+	payload := make([]byte, 4<<20)
+	for i := range payload {
+		payload[i] = byte(i * 7)
+	}
+	u := NewDataURI("image/png", nil, payload, true)
+	streamed, err := NewDataURIReader("image/png", nil, bytes.NewReader(payload), true)
+	if err != nil {
+		t.Fatalf("NewDataURIReader() error = %v", err)
+	}
+	if streamed != u {
+		t.Fatal("NewDataURIReader() differs from NewDataURI()")
+	}
+	if reparsed := MustParse(u.String()); reparsed != u {
+		t.Fatal("Parse(NewDataURI()) is not canonical")
+	}
+	d, err := ParseDataURI(u)
+	if err != nil {
+		t.Fatalf("ParseDataURI() error = %v", err)
+	}
+	var got bytes.Buffer
+	if _, err := io.Copy(&got, d.Reader()); err != nil {
+		t.Fatalf("Reader() error = %v", err)
+	}
+	if !bytes.Equal(got.Bytes(), payload) {
+		t.Fatal("Reader() payload mismatch")
+	}
+}
+
+func TestParseDataURIOctets(t *testing.T) {
+	t.Parallel()
+
+	// Direct conversions keep escapes that canonical parsing would re-encode.
+	d, err := ParseDataURI(URI("data:application/octet-stream,%FF%00%zz"))
+	if err != nil {
+		t.Fatalf("ParseDataURI() error = %v", err)
+	}
+	got, err := d.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	if want := []byte("\xff\x00%zz"); !bytes.Equal(got, want) {
+		t.Fatalf("Bytes() = %q, want %q", got, want)
+	}
+}
@@ -29,6 +29,22 @@ func percentDecode(s string) string {
 func percentDecodeFrom(s string, first int) string {
 	out := make([]byte, 0, len(s))
 	out = append(out, s[:first]...)
+	return bytesString(appendPercentDecodeFrom(out, s, first))
+}
+
+// appendPercentDecode appends s to b with vscode-uri's graceful percent
+// decoding applied.
+func appendPercentDecode(b []byte, s string) []byte {
+	for i := 0; i+2 < len(s); i++ {
+		if s[i] == '%' && isAlnum(s[i+1]) && isAlnum(s[i+2]) {
+			b = append(b, s[:i]...)
+			return appendPercentDecodeFrom(b, s, i)
+		}
+	}
+	return append(b, s...)
+}
+
+func appendPercentDecodeFrom(b []byte, s string, first int) []byte {
 	for i := first; i < len(s); {
 		if i+2 < len(s) && s[i] == '%' && isAlnum(s[i+1]) && isAlnum(s[i+2]) {
 			start := i
@@ -36,19 +52,28 @@ func percentDecodeFrom(s string, first int) string {
 			for i+2 < len(s) && s[i] == '%' && isAlnum(s[i+1]) && isAlnum(s[i+2]) {
 				i += 3
 			}
-			out = append(out, decodePercentRunGraceful(s[start:i])...)
+			b = appendPercentRunGraceful(b, s[start:i])
 			continue
 		}
-		out = append(out, s[i])
+		b = append(b, s[i])
 		i++
 	}
-	return string(out)
+	return b
 }
 
-func decodePercentRunGraceful(run string) string {
+// percentRunStack is the longest run of triplets decoded without allocating
+// scratch space.
+const percentRunStack = 64
+
+func appendPercentRunGraceful(b []byte, run string) []byte {
 	triplets := len(run) / 3
-	buf := make([]byte, triplets)
-	hexOK := make([]bool, triplets)
+	var bufStack [percentRunStack]byte
+	var hexOKStack [percentRunStack]bool
+	buf, hexOK := bufStack[:0], hexOKStack[:0]
+	if triplets > percentRunStack {
+		buf, hexOK = make([]byte, 0, triplets), make([]bool, 0, triplets)
+	}
+	buf, hexOK = buf[:triplets], hexOK[:triplets]
 	for i, j := 0, 0; i < len(run); i, j = i+3, j+1 {
 		hi := hexDecodeTable[run[i+1]]
 		lo := hexDecodeTable[run[i+2]]
@@ -59,14 +84,15 @@ func decodePercentRunGraceful(run string) string {
 	}
 
 	if allTrue(hexOK) && utf8.Valid(buf) {
-		return string(buf)
+		return append(b, buf...)
 	}
 
 	firstValid := firstValidUTF8HexSuffix(buf, hexOK)
 	if firstValid < 0 {
-		return run
+		return append(b, run...)
 	}
-	return run[:firstValid*3] + string(buf[firstValid:])
+	b = append(b, run[:firstValid*3]...)
+	return append(b, buf[firstValid:]...)
 }
 
 func allTrue(values []bool) bool {
@@ -0,0 +1,281 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.lsp.dev/uri"
+	"go.lsp.dev/uri/uritest"
+)
+
+var recordDifferential = flag.Bool("differential.record", false,
+	"add minimized FuzzParseDifferential mismatches to uritest/vectors.json")
+
+// FuzzParseDifferential compares Parse with the pinned vscode-uri, run as the
+// tools/genvectors/server.mjs subprocess. It is skipped unless Node.js and
+// the dependencies of tools/genvectors are installed:
+//
+//	npm ci --prefix tools/genvectors
+//	go test -run FuzzParseDifferential
+//	go test -run '^$' -fuzz FuzzParseDifferential
+//
+// A mismatch is minimized against the reference before it is reported. With
+// -differential.record, minimized inputs that vscode-uri parses are added to
+// the parse section of uritest/vectors.json with the reference's results, so
+// that they keep failing TestVectors until Parse is fixed.
+func FuzzParseDifferential(f *testing.F) {
+	ref := startReference(f)
+	for _, seed := range differentialSeeds(f) {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		if !utf8.ValidString(input) {
+			// The protocol is JSON, which cannot carry invalid UTF-8.
+			return
+		}
+		failing := func(s string) bool {
+			return differentialMismatch(ref.parse(t, s), s) != ""
+		}
+		if !failing(input) {
+			return
+		}
+		minimized := minimizeInput(input, failing)
+		want := ref.parse(t, minimized)
+		if *recordDifferential && want.Error == "" {
+			recordParseVector(t, want.ParseVector)
+		}
+		t.Fatalf("Parse(%q) disagrees with vscode-uri; minimized to %q:\n%s",
+			input, minimized, differentialMismatch(want, minimized))
+	})
+}
+
+// differentialSeeds returns the parse vector inputs, the benchmark corpus,
+// and the committed corpora of the Parse fuzz targets.
+func differentialSeeds(tb testing.TB) []string {
+	tb.Helper()
+	var seeds []string
+	for _, v := range uritest.Corpus().Parse {
+		seeds = append(seeds, v.Input)
+	}
+	corpus, err := os.ReadFile("testdata/corpus/uri_bench.tsv")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	for line := range strings.Lines(string(corpus)) {
+		if _, text, ok := strings.Cut(strings.TrimSpace(line), "\t"); ok && !strings.HasPrefix(line, "#") {
+			seeds = append(seeds, text)
+		}
+	}
+	dirs, err := filepath.Glob("testdata/fuzz/FuzzParse*")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	for _, dir := range dirs {
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+			if err != nil {
+				tb.Fatal(err)
+			}
+			if s, ok := fuzzCorpusString(string(data)); ok {
+				seeds = append(seeds, s)
+			}
+		}
+	}
+	return seeds
+}
+
+// fuzzCorpusString returns the string value of a "go test fuzz v1" corpus
+// file with a single string argument.
+func fuzzCorpusString(data string) (string, bool) {
+	header, value, _ := strings.Cut(strings.TrimSpace(data), "\n")
+	if header != "go test fuzz v1" || !strings.HasPrefix(value, "string(") || !strings.HasSuffix(value, ")") {
+		return "", false
+	}
+	s, err := strconv.Unquote(value[len("string(") : len(value)-1])
+	return s, err == nil
+}
+
+// referenceResult is one response of tools/genvectors/server.mjs.
+type referenceResult struct {
+	uritest.ParseVector
+	Error string `json:"error"`
+}
+
+// referenceProcess is a running tools/genvectors/server.mjs.
+type referenceProcess struct {
+	mu  sync.Mutex
+	in  io.Writer
+	out *bufio.Reader
+}
+
+func startReference(tb testing.TB) *referenceProcess {
+	tb.Helper()
+	node, err := exec.LookPath("node")
+	if err != nil {
+		tb.Skip("node not found; differential testing needs Node.js")
+	}
+	if _, err := os.Stat("tools/genvectors/node_modules/vscode-uri/package.json"); err != nil {
+		tb.Skip("vscode-uri not installed; run npm ci --prefix tools/genvectors")
+	}
+	cmd := exec.Command(node, "tools/genvectors/server.mjs")
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() {
+		in.Close()
+		if err := cmd.Wait(); err != nil {
+			tb.Errorf("reference server: %v", err)
+		}
+	})
+	return &referenceProcess{in: in, out: bufio.NewReader(out)}
+}
+
+// parse returns the reference parse vector for input.
+func (r *referenceProcess) parse(tb testing.TB, input string) referenceResult {
+	tb.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	req, err := json.Marshal(struct {
+		Input string `json:"input"`
+	}{input})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if _, err := r.in.Write(append(req, '\n')); err != nil {
+		tb.Fatalf("reference server: %v", err)
+	}
+	line, err := r.out.ReadBytes('\n')
+	if err != nil {
+		tb.Fatalf("reference server: %v", err)
+	}
+	var res referenceResult
+	if err := json.Unmarshal(line, &res); err != nil {
+		tb.Fatalf("reference server: %v in %q", err, line)
+	}
+	if res.Input != input {
+		tb.Fatalf("reference server answered for %q, want %q", res.Input, input)
+	}
+	return res
+}
+
+// differentialMismatch describes how Parse disagrees with the reference
+// result for input, or returns "" if it agrees.
+func differentialMismatch(want referenceResult, input string) string {
+	u, err := uri.Parse(input)
+	switch {
+	case want.Error != "" && err == nil:
+		return fmt.Sprintf("Parse() = %q, vscode-uri throws %q", u, want.Error)
+	case want.Error == "" && err != nil:
+		return fmt.Sprintf("Parse() error = %v, vscode-uri parses to %q", err, want.String)
+	case err != nil:
+		return ""
+	}
+	got := uritest.ParseVector{
+		Name:             want.Name,
+		Input:            input,
+		Components:       u.Components(),
+		String:           u.String(),
+		StringNoEncoding: u.StringNoEncoding(),
+		FsPathPOSIX:      uri.FsPathFor(u, uri.PlatformPOSIX, false),
+		FsPathWindows:    uri.FsPathFor(u, uri.PlatformWindows, false),
+	}
+	return cmp.Diff(want.ParseVector, got)
+}
+
+// minimizeInput shrinks input while failing reports true for it, removing
+// ever shorter runs of bytes until no single byte can be removed.
+func minimizeInput(input string, failing func(string) bool) string {
+	for n := len(input) / 2; n >= 1; n /= 2 {
+		for i := 0; i+n <= len(input); {
+			if s := input[:i] + input[i+n:]; utf8.ValidString(s) && failing(s) {
+				input = s
+			} else {
+				i++
+			}
+		}
+	}
+	return input
+}
+
+var recordMu sync.Mutex
+
+// recordParseVector adds v to the front of the parse section of
+// uritest/vectors.json unless a vector with the same input exists. The file
+// is edited textually so the layout written by tools/genvectors is kept.
+func recordParseVector(tb testing.TB, v uritest.ParseVector) {
+	tb.Helper()
+	recordMu.Lock()
+	defer recordMu.Unlock()
+
+	const name = "uritest/vectors.json"
+	data, err := os.ReadFile(name)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	vectors, err := uritest.DecodeVectors(data)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	for _, existing := range vectors.Parse {
+		if existing.Input == v.Input {
+			return
+		}
+	}
+	const section = "\"parse\": [\n"
+	at := bytes.Index(data, []byte(section))
+	if at < 0 {
+		tb.Fatalf("%s has no parse section", name)
+	}
+	at += len(section)
+
+	// The fields of uritest.ParseVector follow tools/genvectors/reference.mjs
+	// in order and names.
+	v.Name = "differential " + strconv.Quote(v.Input)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("    ", "  ")
+	if err := enc.Encode(v); err != nil {
+		tb.Fatal(err)
+	}
+	record := append([]byte("    "), bytes.TrimSuffix(buf.Bytes(), []byte("\n"))...)
+	record = append(record, ",\n"...)
+
+	out := append(append(append([]byte(nil), data[:at]...), record...), data[at:]...)
+	if err := os.WriteFile(name, out, 0o644); err != nil {
+		tb.Fatal(err)
+	}
+	tb.Logf("added parse vector for %q to %s", v.Input, name)
+}
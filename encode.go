@@ -4,84 +4,72 @@
 
 package uri
 
-import "strings"
+import "unsafe"
 
 func encodeComponentFast(s string, isPath, isAuthority bool) string {
 	for i := 0; i < len(s); i++ {
 		if !canPassFast(s[i], isPath, isAuthority) {
-			return encodeComponentFastFrom(s, i, isPath, isAuthority)
+			b := make([]byte, 0, len(s)+8)
+			b = append(b, s[:i]...)
+			return bytesString(appendComponentFastFrom(b, s, i, isPath, isAuthority))
 		}
 	}
 	return s
 }
 
-func encodeComponentFastFrom(s string, first int, isPath, isAuthority bool) string {
-	var b strings.Builder
-	b.Grow(len(s) + 8)
-	b.WriteString(s[:first])
-	writeComponentFastFrom(&b, s, first, isPath, isAuthority)
-	return b.String()
-}
-
-func writeComponentFast(b *strings.Builder, s string, isPath, isAuthority bool) {
+func appendComponentFast(b []byte, s string, isPath, isAuthority bool) []byte {
 	for i := 0; i < len(s); i++ {
 		if !canPassFast(s[i], isPath, isAuthority) {
-			b.WriteString(s[:i])
-			writeComponentFastFrom(b, s, i, isPath, isAuthority)
-			return
+			b = append(b, s[:i]...)
+			return appendComponentFastFrom(b, s, i, isPath, isAuthority)
 		}
 	}
-	b.WriteString(s)
+	return append(b, s...)
 }
 
-func writeComponentFastFrom(b *strings.Builder, s string, first int, isPath, isAuthority bool) {
+func appendComponentFastFrom(b []byte, s string, first int, isPath, isAuthority bool) []byte {
 	for i := first; i < len(s); i++ {
 		c := s[i]
 		if canPassFast(c, isPath, isAuthority) {
-			b.WriteByte(c)
+			b = append(b, c)
 			continue
 		}
-		writePercentByte(b, c)
+		b = appendPercentByte(b, c)
 	}
+	return b
 }
 
 func encodeComponentMinimal(s string) string {
 	for i := 0; i < len(s); i++ {
 		if s[i] == '#' || s[i] == '?' {
-			return encodeComponentMinimalFrom(s, i)
+			b := make([]byte, 0, len(s)+4)
+			b = append(b, s[:i]...)
+			return bytesString(appendComponentMinimalFrom(b, s, i))
 		}
 	}
 	return s
 }
 
-func encodeComponentMinimalFrom(s string, first int) string {
-	var b strings.Builder
-	b.Grow(len(s) + 4)
-	b.WriteString(s[:first])
-	writeComponentMinimalFrom(&b, s, first)
-	return b.String()
-}
-
-func writeComponentMinimal(b *strings.Builder, s string) {
+func appendComponentMinimal(b []byte, s string) []byte {
 	for i := 0; i < len(s); i++ {
 		if s[i] == '#' || s[i] == '?' {
-			b.WriteString(s[:i])
-			writeComponentMinimalFrom(b, s, i)
-			return
+			b = append(b, s[:i]...)
+			return appendComponentMinimalFrom(b, s, i)
 		}
 	}
-	b.WriteString(s)
+	return append(b, s...)
 }
 
-func writeComponentMinimalFrom(b *strings.Builder, s string, first int) {
+func appendComponentMinimalFrom(b []byte, s string, first int) []byte {
 	for i := first; i < len(s); i++ {
 		switch s[i] {
 		case '#', '?':
-			writePercentByte(b, s[i])
+			b = appendPercentByte(b, s[i])
 		default:
-			b.WriteByte(s[i])
+			b = append(b, s[i])
 		}
 	}
+	return b
 }
 
 func canPassFast(c byte, isPath, isAuthority bool) bool {
@@ -98,7 +86,13 @@ func canPassFast(c byte, isPath, isAuthority bool) bool {
 	return false
 }
 
-func writePercentByte(b *strings.Builder, c byte) {
+func appendPercentByte(b []byte, c byte) []byte {
 	start := int(c) * 3
-	b.WriteString(percentTriplets[start : start+3])
+	return append(b, percentTriplets[start:start+3]...)
+}
+
+// bytesString returns b as a string without copying, the same conversion
+// strings.Builder.String performs. b must not be modified afterwards.
+func bytesString(b []byte) string {
+	return unsafe.String(unsafe.SliceData(b), len(b))
 }
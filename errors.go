@@ -7,6 +7,9 @@ package uri
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 var (
@@ -18,24 +21,95 @@ var (
 	ErrAuthorityPath = errors.New("uri: authority path must be empty or begin with slash")
 	// ErrPathAuthority reports that a URI path without authority starts with two slashes.
 	ErrPathAuthority = errors.New("uri: path without authority cannot begin with two slashes")
+	// ErrNotDataURI reports that a URI passed to ParseDataURI does not have the data scheme.
+	ErrNotDataURI = errors.New("uri: not a data URI")
+	// ErrInvalidDataURI reports that a data URI has no payload comma or a malformed parameter.
+	ErrInvalidDataURI = errors.New("uri: malformed data URI")
+	// ErrInvalidCharacter reports a byte that RFC 3986 does not allow in its component.
+	ErrInvalidCharacter = errors.New("uri: character not allowed by RFC 3986")
+	// ErrInvalidPercentEncoding reports a '%' not followed by two hexadecimal digits.
+	ErrInvalidPercentEncoding = errors.New("uri: malformed percent-encoding")
+	// ErrInvalidHost reports an authority host that is neither a reg-name nor an IP literal.
+	ErrInvalidHost = errors.New("uri: invalid host")
+	// ErrInvalidPort reports an authority port that is not all decimal digits.
+	ErrInvalidPort = errors.New("uri: port must be decimal digits")
+	// ErrDrivePath reports a Windows drive path such as C:\dir passed where a URI is expected.
+	ErrDrivePath = errors.New("uri: drive path is not a URI")
+	// ErrUserinfo reports an authority with userinfo when ParseOptions disallows it.
+	ErrUserinfo = errors.New("uri: userinfo is not allowed")
+	// ErrTooLong reports input or an authority longer than its Limits allow.
+	ErrTooLong = errors.New("uri: input too long")
+	// ErrTooComplex reports input with more path segments or percent triplets than its Limits allow.
+	ErrTooComplex = errors.New("uri: input too complex")
+	// ErrControlCharacter reports an ASCII control byte when ParseOptions rejects them.
+	ErrControlCharacter = errors.New("uri: control character")
+	// ErrSchemeNotAllowed reports a scheme outside ParseOptions.Schemes or AllowSchemes.
+	ErrSchemeNotAllowed = errors.New("uri: scheme is not allowed")
+	// ErrMissingAuthority reports a URI without authority rejected by RequireAuthority
+	// or by a scheme registered with AuthorityRequired.
+	ErrMissingAuthority = errors.New("uri: authority is missing")
+	// ErrAuthorityNotAllowed reports a URI with an authority whose scheme is
+	// registered with AuthorityForbidden.
+	ErrAuthorityNotAllowed = errors.New("uri: authority is not allowed")
+	// ErrQueryNotAllowed reports a URI with a query rejected by ForbidQuery.
+	ErrQueryNotAllowed = errors.New("uri: query is not allowed")
+	// ErrRelativePath reports a URI path without leading slash rejected by RequireAbsolutePath.
+	ErrRelativePath = errors.New("uri: path is not absolute")
+	// ErrNotFileBacked reports a URI whose scheme is not file-backed, rejected by RequireFileBacked.
+	ErrNotFileBacked = errors.New("uri: scheme is not file-backed")
 )
 
+// Component names reported by Error.Component.
+const (
+	ComponentScheme    = "scheme"
+	ComponentAuthority = "authority"
+	ComponentPath      = "path"
+	ComponentQuery     = "query"
+	ComponentFragment  = "fragment"
+)
+
+// errorExcerptContext is the number of input bytes shown on each side of the
+// offending byte in Error messages.
+const errorExcerptContext = 24
+
 // Error describes a URI validation failure while preserving a typed cause.
 type Error struct {
 	Op    string
 	Input string
 	Err   error
+
+	// Component names the URI component containing the offending byte. It is
+	// empty when the failure is not tied to a position in Input.
+	Component string
+	// Offset is the byte offset of the offending byte in Input. It is only
+	// meaningful when Component is not empty.
+	Offset int
+
+	// Rule names the Validator rule that rejected Input, such as
+	// RuleForbidQuery. It is empty for parse errors.
+	Rule string
 }
 
 // Error returns a human-readable URI error string.
+//
+// Validation errors name the violated rule after Op. Positioned errors print
+// an excerpt of the input around Offset with a caret under the offending byte
+// instead of quoting the whole input.
 func (e *Error) Error() string {
 	if e == nil {
 		return "<nil>"
 	}
+	op := e.Op
+	if e.Rule != "" {
+		op += " " + e.Rule
+	}
+	if e.Component != "" {
+		return fmt.Sprintf("%s: %v at offset %d in %s\n%s", op, e.Err, e.Offset, e.Component, errorExcerpt(e.Input, e.Offset))
+	}
 	if e.Input == "" {
-		return fmt.Sprintf("%s: %v", e.Op, e.Err)
+		return fmt.Sprintf("%s: %v", op, e.Err)
 	}
-	return fmt.Sprintf("%s %q: %v", e.Op, e.Input, e.Err)
+	return fmt.Sprintf("%s %q: %v", op, e.Input, e.Err)
 }
 
 // Unwrap returns the underlying sentinel error.
@@ -49,3 +123,68 @@ func (e *Error) Unwrap() error {
 func uriError(op, input string, err error) error {
 	return &Error{Op: op, Input: input, Err: err}
 }
+
+func uriErrorAt(op, input string, err error, offset int, component string) error {
+	return &Error{Op: op, Input: input, Err: err, Component: component, Offset: offset}
+}
+
+// locateError attaches the position of a component validation failure in the
+// raw input to err when err does not carry one yet.
+func locateError(err error, raw *rawParts) error {
+	if err == nil {
+		return nil
+	}
+	// A type assertion rather than errors.As keeps e on the stack, so a
+	// successful parse never pays for positioning errors.
+	e, ok := err.(*Error)
+	if !ok || e.Component != "" {
+		return err
+	}
+	switch {
+	case errors.Is(e.Err, ErrMissingScheme):
+		e.Offset, e.Component = 0, ComponentScheme
+	case errors.Is(e.Err, ErrInvalidScheme):
+		e.Offset, e.Component = invalidSchemeOffset(raw.scheme), ComponentScheme
+	case errors.Is(e.Err, ErrAuthorityPath), errors.Is(e.Err, ErrPathAuthority):
+		e.Offset, e.Component = raw.pathStart, ComponentPath
+	}
+	return err
+}
+
+func invalidSchemeOffset(scheme string) int {
+	for i := 0; i < len(scheme); i++ {
+		if !validScheme(scheme[i : i+1]) {
+			return i
+		}
+	}
+	return 0
+}
+
+// errorExcerpt renders up to errorExcerptContext bytes of input on each side of
+// offset as an ASCII-quoted line followed by a caret line.
+func errorExcerpt(input string, offset int) string {
+	offset = min(max(offset, 0), len(input))
+	start := max(offset-errorExcerptContext, 0)
+	for start > 0 && !utf8.RuneStart(input[start]) {
+		start--
+	}
+	end := min(offset+errorExcerptContext, len(input))
+	for end < len(input) && !utf8.RuneStart(input[end]) {
+		end++
+	}
+
+	var b strings.Builder
+	b.WriteByte('\t')
+	if start > 0 {
+		b.WriteString("...")
+	}
+	column := b.Len() + len(strconv.QuoteToASCII(input[start:offset])) - 1
+	b.WriteString(strconv.QuoteToASCII(input[start:end]))
+	if end < len(input) {
+		b.WriteString("...")
+	}
+	b.WriteString("\n\t")
+	b.WriteString(strings.Repeat(" ", column-1))
+	b.WriteByte('^')
+	return b.String()
+}
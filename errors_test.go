@@ -0,0 +1,110 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrorPosition(t *testing.T) {
+	tests := map[string]struct {
+		input         string
+		strict        bool
+		wantErr       error
+		wantOffset    int
+		wantComponent string
+	}{
+		"error: missing scheme": {
+			input:         "path/only",
+			strict:        true,
+			wantErr:       ErrMissingScheme,
+			wantOffset:    0,
+			wantComponent: ComponentScheme,
+		},
+		"error: invalid scheme byte": {
+			input:         "fäil:path",
+			wantErr:       ErrInvalidScheme,
+			wantOffset:    1,
+			wantComponent: ComponentScheme,
+		},
+		"error: invalid scheme after canonical prefix": {
+			input:         "foo bar://host/p",
+			wantErr:       ErrInvalidScheme,
+			wantOffset:    3,
+			wantComponent: ComponentScheme,
+		},
+		"error: path without authority starts with two slashes": {
+			input:         "foo:////fail",
+			wantErr:       ErrPathAuthority,
+			wantOffset:    6,
+			wantComponent: ComponentPath,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var err error
+			if tt.strict {
+				_, err = ParseStrict(tt.input)
+			} else {
+				_, err = Parse(tt.input)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			var uerr *Error
+			if !errors.As(err, &uerr) {
+				t.Fatalf("Parse(%q) error = %T, want *Error", tt.input, err)
+			}
+			if uerr.Offset != tt.wantOffset || uerr.Component != tt.wantComponent {
+				t.Fatalf("Parse(%q) position = %d in %q, want %d in %q", tt.input, uerr.Offset, uerr.Component, tt.wantOffset, tt.wantComponent)
+			}
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	long := strings.Repeat("a", 40)
+	tests := map[string]struct {
+		err  *Error
+		want string
+	}{
+		"success: unpositioned keeps quoted input": {
+			err:  &Error{Op: "parse", Input: "x", Err: ErrMissingScheme},
+			want: `parse "x": uri: scheme is missing`,
+		},
+		"success: unpositioned without input": {
+			err:  &Error{Op: "from", Err: ErrMissingScheme},
+			want: "from: uri: scheme is missing",
+		},
+		"success: rule follows op": {
+			err:  &Error{Op: "validate", Input: "file:///a", Err: ErrMissingAuthority, Rule: RuleRequireAuthority},
+			want: `validate RequireAuthority "file:///a": uri: authority is missing`,
+		},
+		"success: caret under offending byte": {
+			err:  &Error{Op: "parse", Input: "foo bar:x", Err: ErrInvalidScheme, Component: ComponentScheme, Offset: 3},
+			want: "parse: uri: scheme contains illegal characters at offset 3 in scheme\n\t\"foo bar:x\"\n\t    ^",
+		},
+		"success: non-ascii is escaped before caret": {
+			err:  &Error{Op: "parse", Input: "fä il:x", Err: ErrInvalidScheme, Component: ComponentScheme, Offset: 3},
+			want: "parse: uri: scheme contains illegal characters at offset 3 in scheme\n\t\"f\\u00e4 il:x\"\n\t        ^",
+		},
+		"success: long input is trimmed around offset": {
+			err: &Error{Op: "parse", Input: long + " " + long, Err: ErrInvalidScheme, Component: ComponentScheme, Offset: 40},
+			want: "parse: uri: scheme contains illegal characters at offset 40 in scheme\n\t...\"" +
+				strings.Repeat("a", 24) + " " + strings.Repeat("a", 23) + "\"...\n\t" + strings.Repeat(" ", 28) + "^",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.err.Error(); got != tt.want {
+				t.Fatalf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,75 +7,76 @@ package uri
 import "strings"
 
 func formatComponents(c *Components, skipEncoding bool) string {
-	var b strings.Builder
-	b.Grow(len(c.Scheme) + len(c.Authority) + len(c.Path) + len(c.Query) + len(c.Fragment) + 8)
+	b := make([]byte, 0, len(c.Scheme)+len(c.Authority)+len(c.Path)+len(c.Query)+len(c.Fragment)+8)
+	return bytesString(appendComponents(b, c, skipEncoding))
+}
+
+func appendComponents(b []byte, c *Components, skipEncoding bool) []byte {
 	if c.Scheme != "" {
-		b.WriteString(c.Scheme)
-		b.WriteByte(':')
+		b = append(b, c.Scheme...)
+		b = append(b, ':')
 	}
 	if c.Authority != "" || c.Scheme == schemeFile {
-		b.WriteString("//")
+		b = append(b, "//"...)
 	}
 	if c.Authority != "" {
-		writeAuthority(&b, c.Authority, skipEncoding)
+		b = appendAuthority(b, c.Authority, skipEncoding)
 	}
 	path := formatPathDrive(c.Path)
 	if skipEncoding {
-		writeComponentMinimal(&b, path)
+		b = appendComponentMinimal(b, path)
 	} else {
-		writeComponentFast(&b, path, true, false)
+		b = appendComponentFast(b, path, true, false)
 	}
 	if c.Query != "" {
-		b.WriteByte('?')
+		b = append(b, '?')
 		if skipEncoding {
-			writeComponentMinimal(&b, c.Query)
+			b = appendComponentMinimal(b, c.Query)
 		} else {
-			writeComponentFast(&b, c.Query, false, false)
+			b = appendComponentFast(b, c.Query, false, false)
 		}
 	}
 	if c.Fragment != "" {
-		b.WriteByte('#')
+		b = append(b, '#')
 		if skipEncoding {
-			b.WriteString(c.Fragment)
+			b = append(b, c.Fragment...)
 		} else {
-			writeComponentFast(&b, c.Fragment, false, false)
+			b = appendComponentFast(b, c.Fragment, false, false)
 		}
 	}
-	return b.String()
+	return b
 }
 
-func writeAuthority(b *strings.Builder, authority string, skipEncoding bool) {
+func appendAuthority(b []byte, authority string, skipEncoding bool) []byte {
 	at := strings.IndexByte(authority, '@')
 	if at >= 0 {
 		userinfo := authority[:at]
 		colon := strings.LastIndexByte(userinfo, ':')
 		if colon < 0 {
-			writeAuthorityPart(b, userinfo, false, skipEncoding)
+			b = appendAuthorityPart(b, userinfo, false, skipEncoding)
 		} else {
-			writeAuthorityPart(b, userinfo[:colon], false, skipEncoding)
-			b.WriteByte(':')
-			writeAuthorityPart(b, userinfo[colon+1:], true, skipEncoding)
+			b = appendAuthorityPart(b, userinfo[:colon], false, skipEncoding)
+			b = append(b, ':')
+			b = appendAuthorityPart(b, userinfo[colon+1:], true, skipEncoding)
 		}
-		b.WriteByte('@')
+		b = append(b, '@')
 		authority = authority[at+1:]
 	}
 
 	authority = strings.ToLower(authority)
 	colon := strings.LastIndexByte(authority, ':')
 	if colon < 0 {
-		writeAuthorityPart(b, authority, true, skipEncoding)
-		return
+		return appendAuthorityPart(b, authority, true, skipEncoding)
 	}
-	writeAuthorityPart(b, authority[:colon], true, skipEncoding)
-	b.WriteString(authority[colon:])
+	b = appendAuthorityPart(b, authority[:colon], true, skipEncoding)
+	return append(b, authority[colon:]...)
 }
 
-func writeAuthorityPart(b *strings.Builder, s string, isAuthority, skipEncoding bool) {
+func appendAuthorityPart(b []byte, s string, isAuthority, skipEncoding bool) []byte {
 	if skipEncoding {
-		writeComponentMinimal(b, s)
-		return
+		return appendComponentMinimal(b, s)
 	}
-	writeComponentFast(b, s, false, isAuthority)
+	return appendComponentFast(b, s, false, isAuthority)
 }
 
 func formatPathDrive(path string) string {
@@ -88,6 +89,12 @@ func formatPathDrive(path string) string {
 	return path
 }
 
+// hasUpperDrive reports whether formatPathDrive would lowercase path.
+func hasUpperDrive(path string) bool {
+	return len(path) >= 3 && path[0] == '/' && path[2] == ':' && isUpperASCII(path[1]) ||
+		len(path) >= 2 && path[1] == ':' && isUpperASCII(path[0])
+}
+
 func isUpperASCII(c byte) bool {
 	return c >= 'A' && c <= 'Z'
 }
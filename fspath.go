@@ -85,33 +85,54 @@ func (u URI) FsPath() string {
 //
 // Because URI values do not retain parse-history-only casing, UNC authorities
 // and drive letters use the canonical casing exposed by Authority and Path.
+// Authorities of file URIs and of schemes registered as file-backed are UNC
+// hosts; other authorities are dropped.
+//
+// Code that also reads other components of u can call URI.Parsed and
+// Parsed.FsPathFor to split u only once.
 func FsPathFor(u URI, platform Platform, keepDriveLetterCasing bool) string {
 	if value, ok := fsPathFast(u, platform, keepDriveLetterCasing); ok {
 		return value
 	}
+	return u.Parsed().FsPathFor(platform, keepDriveLetterCasing)
+}
 
-	scheme := u.Scheme()
-	authority := u.Authority()
-	path := u.Path()
+// AppendFsPath appends FsPathFor(u, platform, false) to b and returns the
+// extended buffer. It decodes straight into b and allocates only when b must
+// grow or a single percent-encoded run is unusually long.
+func (u URI) AppendFsPath(b []byte, platform Platform) []byte {
+	return u.Parsed().AppendFsPath(b, platform)
+}
 
-	var value string
-	switch {
-	case scheme == schemeFile && authority != "" && len(path) > 1:
-		value = "//" + authority + path
-	case len(path) >= 3 && path[0] == '/' && isASCIIAlpha(path[1]) && path[2] == ':':
-		if keepDriveLetterCasing {
-			value = path[1:]
-		} else {
-			value = string(toLowerASCII(path[1])) + path[2:]
+func appendFsPath(b []byte, raw *rawParts, platform Platform, keepDriveLetterCasing bool) []byte {
+	start := len(b)
+	if raw.authority != "" && isFileBackedScheme(raw.scheme) {
+		b = append(b, "//"...)
+		b = appendPercentDecode(b, raw.authority)
+		pathStart := len(b)
+		b = appendPercentDecode(b, raw.path)
+		if len(b)-pathStart <= 1 {
+			b = append(b[:start], b[pathStart:]...)
 		}
-	default:
-		value = path
+	} else {
+		b = appendPercentDecode(b, raw.path)
+	}
+
+	if path := b[start:]; len(path) >= 3 && path[0] == '/' && isASCIIAlpha(path[1]) && path[2] == ':' {
+		if !keepDriveLetterCasing {
+			path[1] = toLowerASCII(path[1])
+		}
+		b = append(b[:start], b[start+1:]...)
 	}
 
 	if platform == PlatformWindows {
-		value = strings.ReplaceAll(value, "/", "\\")
+		for i := start; i < len(b); i++ {
+			if b[i] == '/' {
+				b[i] = '\\'
+			}
+		}
 	}
-	return value
+	return b
 }
 
 func fsPathFast(u URI, platform Platform, keepDriveLetterCasing bool) (string, bool) {
@@ -122,8 +143,10 @@ func fsPathFast(u URI, platform Platform, keepDriveLetterCasing bool) (string, b
 	if len(s) < len(fileURIAbsolutePrefix) || s[:len(fileURIAbsolutePrefix)] != fileURIAbsolutePrefix {
 		return "", false
 	}
+	// Canonical paths that pass unescaped have nothing to decode and no
+	// query or fragment.
 	path := s[fileURIPathStart:]
-	if strings.ContainsAny(path, "%?#") {
+	if pathPassPrefix(path) != len(path) {
 		return "", false
 	}
 	if len(path) > 1 && path[1] == '/' {
@@ -5,6 +5,8 @@
 package uri
 
 import (
+	"slices"
+	"strings"
 	"testing"
 	"unicode/utf8"
 )
@@ -52,3 +54,167 @@ func FuzzParseRoundTripCanonical(f *testing.F) {
 		}
 	})
 }
+
+func FuzzParseFastMatchesFormat(f *testing.F) {
+	for _, seed := range []string{
+		"https://host/p?name=ferret#f",
+		"https://host/p?a=1&b=2",
+		"file:///Users/me/go/pkg/mod/example.com/mod@v1.2.3/file.go",
+		"file:///space path/needs#fragment?not-query",
+		"foo:a b#c d",
+		"HTTPS://Host/p",
+		"file:///C:/x?y",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, strict := range []bool{false, true} {
+			raw := splitRaw(input)
+			u, ok, err := parseCanonicalFast(input, &raw, strict)
+			if !ok || err != nil {
+				continue
+			}
+			c := Components{
+				Scheme:    raw.scheme,
+				Authority: decodeComponent(raw.authority),
+				Path:      decodeComponent(raw.path),
+				Query:     decodeComponent(raw.query),
+				Fragment:  decodeComponent(raw.fragment),
+			}
+			want, err := newURI(&c, strict, "parse", input)
+			if err != nil {
+				t.Fatalf("newURI(%q) error = %v, fast path accepted it as %q", input, err, u)
+			}
+			if u != want {
+				t.Fatalf("parseCanonicalFast(%q) = %q, want %q", input, u, want)
+			}
+		}
+	})
+}
+
+func FuzzPassPrefixMatchesScalar(f *testing.F) {
+	for _, seed := range []string{
+		"/Users/me/go/pkg/mod/example.com/mod@v1.2.3/file.go",
+		"name=ferret",
+		"Z\xc3\xbcrich/c%23/plugin.json",
+		"~-._/azAZ09",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, isPath := range []bool{false, true} {
+			if got, want := passPrefix(input, isPath), scalarPassPrefix(input, isPath); got != want {
+				t.Fatalf("passPrefix(%q, %t) = %d, want %d", input, isPath, got, want)
+			}
+		}
+	})
+}
+
+func FuzzFilesForMatchesFileFor(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"/home/user/project/a.go", "/home/user/project/b.go"},
+		{`C:\Users\me\a.go`, `c:\Users\me\b.go`},
+		{"C:", "C:/x"},
+		{`\\server\share\x.go`, "relative/x.go"},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+	f.Fuzz(func(t *testing.T, first, second string) {
+		paths := []string{first, second, first}
+		for _, platform := range []Platform{PlatformPOSIX, PlatformWindows} {
+			var want []URI
+			for _, path := range paths {
+				u, ok := fileForNoPanic(platform, path)
+				if !ok {
+					return
+				}
+				want = append(want, u)
+			}
+			i := 0
+			for got := range FilesFor(platform, slices.Values(paths)) {
+				if got != want[i] {
+					t.Fatalf("FilesFor(%v) item %d (%q) = %q, want %q", platform, i, paths[i], got, want[i])
+				}
+				i++
+			}
+		}
+	})
+}
+
+func FuzzParseAllMatchesParse(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"file:///home/user/a.go", "https://Host/a%20b?q=1#f"},
+		{"untitled:untitled-1", "file:////shares/files/p.cs"},
+		{"/home/%41%2541", "HTTP://x/%E6%97%A5"},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+	f.Fuzz(func(t *testing.T, first, second string) {
+		inputs := []string{first, second, first}
+		i := 0
+		for got, err := range ParseAll(slices.Values(inputs)) {
+			want, wantErr := Parse(inputs[i])
+			if got != want || (err == nil) != (wantErr == nil) {
+				t.Fatalf("ParseAll() item %d (%q) = %q, %v, want %q, %v", i, inputs[i], got, err, want, wantErr)
+			}
+			i++
+		}
+	})
+}
+
+// fileForNoPanic reports false where FileFor panics, as vscode-uri throws,
+// for paths that leave "//" at the start of the path after the authority.
+func fileForNoPanic(platform Platform, path string) (u URI, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return FileFor(platform, path), true
+}
+
+func FuzzAppendMatchesComponents(f *testing.F) {
+	for _, seed := range []string{
+		"file:///home/user/x.go",
+		"https://user:PW@Host/p?q=%23#f%3F",
+		"file://shares/files/c%23/p.cs",
+		"file:///C:/x%20y",
+		"http://a%40B@host/",
+		"foo:%E2%82%AC%FF?x",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		u, err := Parse(input)
+		if err != nil {
+			return
+		}
+		components := u.Components()
+		prefix := []byte("prefix")
+		if got, want := string(u.AppendNoEncoding(prefix)), "prefix"+formatComponents(&components, true); got != want {
+			t.Fatalf("AppendNoEncoding(%q) = %q, want %q", u.String(), got, want)
+		}
+		for _, platform := range []Platform{PlatformPOSIX, PlatformWindows} {
+			if got, want := string(u.AppendFsPath(prefix, platform)), "prefix"+fsPathFromComponents(&components, platform); got != want {
+				t.Fatalf("AppendFsPath(%q, %d) = %q, want %q", u.String(), platform, got, want)
+			}
+		}
+	})
+}
+
+// fsPathFromComponents is the reference uriToFsPath over decoded components.
+func fsPathFromComponents(c *Components, platform Platform) string {
+	var value string
+	switch {
+	case c.Authority != "" && len(c.Path) > 1 && isFileBackedScheme(c.Scheme):
+		value = "//" + c.Authority + c.Path
+	case len(c.Path) >= 3 && c.Path[0] == '/' && isASCIIAlpha(c.Path[1]) && c.Path[2] == ':':
+		value = string(toLowerASCII(c.Path[1])) + c.Path[2:]
+	default:
+		value = c.Path
+	}
+	if platform == PlatformWindows {
+		value = strings.ReplaceAll(value, "/", "\\")
+	}
+	return value
+}
@@ -0,0 +1,11 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gomod maps file URIs to and from Go toolchain and module cache
+// locations.
+//
+// The helpers are pure string functions over canonical URIs: they never
+// consult the environment, runtime.GOROOT, the go command, or the filesystem.
+// Callers supply GOROOT, GOMODCACHE, and GOPATH explicitly.
+package gomod // import "go.lsp.dev/uri/gomod"
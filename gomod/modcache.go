@@ -0,0 +1,141 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gomod
+
+import (
+	"strings"
+
+	"go.lsp.dev/uri"
+)
+
+// ModuleOf reports the module path, version, and slash-separated path relative
+// to the module root for u when u names a file inside the extracted module
+// cache rooted at the gomodcache filesystem path.
+//
+// gomodcache may use slash or backslash separators. Module paths and versions
+// are returned unescaped, so example.com/!azure@v1.0.0 yields
+// example.com/Azure and v1.0.0. Files under gomodcache/cache, such as
+// downloaded zips, are not reported as module files.
+func ModuleOf(u uri.URI, gomodcache string) (modulePath, version, relPath string, ok bool) {
+	rel, ok := within(u, cacheRoot(gomodcache))
+	if !ok || rel == "" || strings.HasPrefix(rel, "cache/") {
+		return "", "", "", false
+	}
+	at := strings.IndexByte(rel, '@')
+	if at <= 0 || rel[at-1] == '/' {
+		return "", "", "", false
+	}
+	escVersion, relPath, _ := strings.Cut(rel[at+1:], "/")
+	modulePath, ok = unescape(rel[:at])
+	if !ok {
+		return "", "", "", false
+	}
+	version, ok = unescape(escVersion)
+	if !ok || version == "" {
+		return "", "", "", false
+	}
+	return modulePath, version, relPath, true
+}
+
+// ModCacheURI returns the file URI of rel inside the extracted copy of
+// modulePath at version in the module cache rooted at the gomodcache
+// filesystem path. It is the inverse of ModuleOf.
+//
+// modulePath and version are escaped with the module cache's "!" encoding of
+// uppercase letters. rel is a slash-separated path and may be empty to name
+// the module root.
+func ModCacheURI(gomodcache, modulePath, version, rel string) uri.URI {
+	elem := escape(modulePath) + "@" + escape(version)
+	u, err := uri.JoinPath(cacheRoot(gomodcache), elem, rel)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// cacheRoot returns the file URI for the gomodcache filesystem path.
+//
+// Windows slash conversion is applied unconditionally so that callers can pass
+// GOMODCACHE as reported by either platform's go env.
+func cacheRoot(gomodcache string) uri.URI {
+	return uri.FileFor(uri.PlatformWindows, strings.TrimRight(gomodcache, `/\`))
+}
+
+// within returns the slash-separated path of u relative to root when u is a
+// file URI located at or below root.
+func within(u, root uri.URI) (string, bool) {
+	if !u.IsFile() || !root.IsFile() || u.Authority() != root.Authority() {
+		return "", false
+	}
+	path, rootPath := u.Path(), strings.TrimSuffix(root.Path(), "/")
+	if path == rootPath {
+		return "", true
+	}
+	rel, ok := strings.CutPrefix(path, rootPath+"/")
+	if !ok {
+		return "", false
+	}
+	return rel, true
+}
+
+// escape applies the module cache case encoding, replacing each uppercase
+// ASCII letter with an exclamation mark followed by its lowercase form.
+func escape(s string) string {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if isUpper(s[i]) {
+			n++
+		}
+	}
+	if n == 0 {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s) + n)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isUpper(c) {
+			b.WriteByte('!')
+			c += 'a' - 'A'
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// unescape reverses escape. It reports false for uppercase letters and for
+// exclamation marks not followed by a lowercase letter, which the go command
+// never writes.
+func unescape(s string) (string, bool) {
+	if strings.IndexByte(s, '!') < 0 {
+		for i := 0; i < len(s); i++ {
+			if isUpper(s[i]) {
+				return "", false
+			}
+		}
+		return s, true
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isUpper(c):
+			return "", false
+		case c == '!':
+			if i+1 >= len(s) || s[i+1] < 'a' || s[i+1] > 'z' {
+				return "", false
+			}
+			i++
+			c = s[i] - ('a' - 'A')
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), true
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gomod
+
+import (
+	"testing"
+
+	"go.lsp.dev/uri"
+)
+
+func TestModuleOf(t *testing.T) {
+	tests := map[string]struct {
+		uri        string
+		gomodcache string
+		wantPath   string
+		wantVer    string
+		wantRel    string
+		wantOK     bool
+	}{
+		"success: canonical escaped at sign": {
+			uri:        "file:///Users/me/go/pkg/mod/example.com/mod%40v1.2.3/file.go",
+			gomodcache: "/Users/me/go/pkg/mod",
+			wantPath:   "example.com/mod",
+			wantVer:    "v1.2.3",
+			wantRel:    "file.go",
+			wantOK:     true,
+		},
+		"success: raw at sign and trailing cache slash": {
+			uri:        "file:///Users/me/go/pkg/mod/example.com/mod@v1.2.3/sub/pkg/x.go",
+			gomodcache: "/Users/me/go/pkg/mod/",
+			wantPath:   "example.com/mod",
+			wantVer:    "v1.2.3",
+			wantRel:    "sub/pkg/x.go",
+			wantOK:     true,
+		},
+		"success: uppercase letters are unescaped": {
+			uri:        "file:///home/me/go/pkg/mod/github.com/!burnt!sushi/toml@v1.6.0/decode.go",
+			gomodcache: "/home/me/go/pkg/mod",
+			wantPath:   "github.com/BurntSushi/toml",
+			wantVer:    "v1.6.0",
+			wantRel:    "decode.go",
+			wantOK:     true,
+		},
+		"success: escaped version": {
+			uri:        "file:///m/example.com/x@v0.0.0-2026!r!c1/x.go",
+			gomodcache: "/m",
+			wantPath:   "example.com/x",
+			wantVer:    "v0.0.0-2026RC1",
+			wantRel:    "x.go",
+			wantOK:     true,
+		},
+		"success: module root": {
+			uri:        "file:///m/example.com/x@v1.0.0",
+			gomodcache: "/m",
+			wantPath:   "example.com/x",
+			wantVer:    "v1.0.0",
+			wantOK:     true,
+		},
+		"success: windows cache path": {
+			uri:        "file:///c%3A/Users/me/go/pkg/mod/golang.org/x/tools%40v0.45.0/go/packages/packages.go",
+			gomodcache: `C:\Users\me\go\pkg\mod`,
+			wantPath:   "golang.org/x/tools",
+			wantVer:    "v0.45.0",
+			wantRel:    "go/packages/packages.go",
+			wantOK:     true,
+		},
+		"error: outside cache": {
+			uri:        "file:///home/me/src/example.com/x@v1.0.0/x.go",
+			gomodcache: "/home/me/go/pkg/mod",
+		},
+		"error: sibling directory sharing prefix": {
+			uri:        "file:///m2/example.com/x@v1.0.0/x.go",
+			gomodcache: "/m",
+		},
+		"error: download cache": {
+			uri:        "file:///m/cache/download/example.com/x/@v/v1.0.0.zip",
+			gomodcache: "/m",
+		},
+		"error: uppercase letter in escaped path": {
+			uri:        "file:///m/github.com/BurntSushi/toml@v1.6.0/decode.go",
+			gomodcache: "/m",
+		},
+		"error: dangling exclamation mark": {
+			uri:        "file:///m/example.com/x!@v1.0.0/x.go",
+			gomodcache: "/m",
+		},
+		"error: missing version": {
+			uri:        "file:///m/example.com/x@/x.go",
+			gomodcache: "/m",
+		},
+		"error: not a file URI": {
+			uri:        "https://host/m/example.com/x@v1.0.0/x.go",
+			gomodcache: "/m",
+		},
+		"error: unc authority does not match": {
+			uri:        "file://server/m/example.com/x@v1.0.0/x.go",
+			gomodcache: "/m",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			gotPath, gotVer, gotRel, ok := ModuleOf(uri.MustParse(tt.uri), tt.gomodcache)
+			if ok != tt.wantOK {
+				t.Fatalf("ModuleOf() ok = %t, want %t", ok, tt.wantOK)
+			}
+			if gotPath != tt.wantPath || gotVer != tt.wantVer || gotRel != tt.wantRel {
+				t.Fatalf("ModuleOf() = (%q, %q, %q), want (%q, %q, %q)", gotPath, gotVer, gotRel, tt.wantPath, tt.wantVer, tt.wantRel)
+			}
+		})
+	}
+}
+
+func TestModCacheURI(t *testing.T) {
+	tests := map[string]struct {
+		gomodcache string
+		modulePath string
+		version    string
+		rel        string
+		want       string
+	}{
+		"success: gomodcache file": {
+			gomodcache: "/Users/me/go/pkg/mod",
+			modulePath: "example.com/mod",
+			version:    "v1.2.3",
+			rel:        "file.go",
+			want:       "file:///Users/me/go/pkg/mod/example.com/mod%40v1.2.3/file.go",
+		},
+		"success: uppercase module path is escaped": {
+			gomodcache: "/home/me/go/pkg/mod/",
+			modulePath: "github.com/BurntSushi/toml",
+			version:    "v1.6.0",
+			rel:        "decode.go",
+			want:       "file:///home/me/go/pkg/mod/github.com/%21burnt%21sushi/toml%40v1.6.0/decode.go",
+		},
+		"success: module root": {
+			gomodcache: "/m",
+			modulePath: "example.com/x",
+			version:    "v1.0.0",
+			want:       "file:///m/example.com/x%40v1.0.0",
+		},
+		"success: windows cache path": {
+			gomodcache: `C:\Users\me\go\pkg\mod`,
+			modulePath: "golang.org/x/tools",
+			version:    "v0.45.0",
+			rel:        "go/packages/packages.go",
+			want:       "file:///c%3A/Users/me/go/pkg/mod/golang.org/x/tools%40v0.45.0/go/packages/packages.go",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := ModCacheURI(tt.gomodcache, tt.modulePath, tt.version, tt.rel)
+			if got.String() != tt.want {
+				t.Fatalf("ModCacheURI() = %q, want %q", got.String(), tt.want)
+			}
+			modulePath, version, rel, ok := ModuleOf(got, tt.gomodcache)
+			if !ok || modulePath != tt.modulePath || version != tt.version || rel != tt.rel {
+				t.Fatalf("ModuleOf(ModCacheURI()) = (%q, %q, %q, %t), want (%q, %q, %q, true)",
+					modulePath, version, rel, ok, tt.modulePath, tt.version, tt.rel)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gomod
+
+import (
+	"strings"
+	"testing"
+
+	"go.lsp.dev/uri"
+	"go.lsp.dev/uri/uritest"
+)
+
+// modCacheCase is a module cache location split from a path generated by
+// uritest.Generator.ModCachePath, with unescaped module path and version.
+type modCacheCase struct {
+	path                                 string
+	platform                             uri.Platform
+	gomodcache, modulePath, version, rel string
+}
+
+func genModCacheCase(g *uritest.Generator) modCacheCase {
+	path, platform := g.ModCachePath()
+	sep := "/"
+	if platform == uri.PlatformWindows {
+		sep = `\`
+	}
+	root, elem, _ := strings.Cut(path, sep+"mod"+sep)
+	escModule, rest, _ := strings.Cut(elem, "@")
+	version, rel, _ := strings.Cut(rest, sep)
+	modulePath, _ := unescape(strings.ReplaceAll(escModule, sep, "/"))
+	gomodcache := root + sep + "mod"
+	if g.Intn(2) == 0 {
+		gomodcache += sep
+	}
+	return modCacheCase{
+		path:       path,
+		platform:   platform,
+		gomodcache: gomodcache,
+		modulePath: modulePath,
+		version:    version,
+		rel:        strings.ReplaceAll(rel, sep, "/"),
+	}
+}
+
+func TestModCacheProperties(t *testing.T) {
+	t.Parallel()
+
+	n := 2000
+	if testing.Short() {
+		n = 200
+	}
+	for seed := range uint64(n) {
+		c := genModCacheCase(uritest.NewGenerator(seed))
+		u := ModCacheURI(c.gomodcache, c.modulePath, c.version, c.rel)
+		if f := uri.FileFor(c.platform, c.path); f != u {
+			t.Fatalf("seed %d: ModCacheURI(%q, %q, %q, %q) = %q, want %q",
+				seed, c.gomodcache, c.modulePath, c.version, c.rel, u, f)
+		}
+
+		// ModuleOf inverts ModCacheURI.
+		modulePath, version, rel, ok := ModuleOf(u, c.gomodcache)
+		if !ok || modulePath != c.modulePath || version != c.version || rel != c.rel {
+			t.Fatalf("seed %d: ModuleOf(%q) = %q, %q, %q, %t, want %q, %q, %q, true",
+				seed, u, modulePath, version, rel, ok, c.modulePath, c.version, c.rel)
+		}
+
+		// Concrete inverts Virtual for files in the module cache.
+		roots := &Roots{GOMODCACHE: cacheRoot(c.gomodcache)}
+		v, ok := roots.Virtual(u)
+		if !ok || v.Scheme() != SchemeGOMODCACHE {
+			t.Fatalf("seed %d: Virtual(%q) = %q, %t", seed, u, v, ok)
+		}
+		if w, ok := roots.Concrete(v); !ok || w != u {
+			t.Fatalf("seed %d: Concrete(Virtual(%q)) = %q, %t", seed, u, w, ok)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gomod
+
+import (
+	"strings"
+
+	"go.lsp.dev/uri"
+)
+
+// Portable virtual schemes for files under GOROOT and GOMODCACHE.
+//
+// A virtual URI carries the slash-separated path relative to its root, so
+// goroot:/src/net/http/server.go names the same file for a client and server
+// whose GOROOT directories differ.
+const (
+	SchemeGOROOT     = "goroot"
+	SchemeGOMODCACHE = "gomodcache"
+)
+
+// Kind classifies the Go location that contains a file URI.
+type Kind uint8
+
+const (
+	// KindOther reports that no configured root contains the URI.
+	KindOther Kind = iota
+	// KindGOROOT reports a file in the Go toolchain tree.
+	KindGOROOT
+	// KindGOMODCACHE reports a file in the module cache.
+	KindGOMODCACHE
+	// KindGOPATH reports a file in a GOPATH entry.
+	KindGOPATH
+	// KindWorkspace reports a file in a workspace folder.
+	KindWorkspace
+)
+
+// String returns the name of k.
+func (k Kind) String() string {
+	switch k {
+	case KindGOROOT:
+		return "GOROOT"
+	case KindGOMODCACHE:
+		return "GOMODCACHE"
+	case KindGOPATH:
+		return "GOPATH"
+	case KindWorkspace:
+		return "workspace"
+	default:
+		return "other"
+	}
+}
+
+// Roots holds the root directories, as file URIs, used to classify and
+// rewrite file URIs. Zero URIs are ignored.
+type Roots struct {
+	GOROOT     uri.URI
+	GOMODCACHE uri.URI
+	GOPATH     []uri.URI
+	Workspace  []uri.URI
+}
+
+// Classify reports the kind of the innermost root containing u, together
+// with that root.
+//
+// Nested roots are common: the default module cache lives inside the first
+// GOPATH entry and workspaces may live inside GOPATH/src. The longest
+// matching root wins, so those files classify as KindGOMODCACHE and
+// KindWorkspace rather than KindGOPATH.
+func (r *Roots) Classify(u uri.URI) (Kind, uri.URI) {
+	kind, root, best := KindOther, uri.URI(""), -1
+	consider := func(k Kind, candidate uri.URI) {
+		if candidate.IsZero() {
+			return
+		}
+		if _, ok := within(u, candidate); ok && len(candidate) > best {
+			kind, root, best = k, candidate, len(candidate)
+		}
+	}
+	consider(KindGOROOT, r.GOROOT)
+	consider(KindGOMODCACHE, r.GOMODCACHE)
+	for _, w := range r.Workspace {
+		consider(KindWorkspace, w)
+	}
+	for _, p := range r.GOPATH {
+		consider(KindGOPATH, p)
+	}
+	return kind, root
+}
+
+// Virtual rewrites a file URI under GOROOT or GOMODCACHE to its portable
+// goroot: or gomodcache: form. Query and fragment are kept. It reports false
+// for URIs that classify as any other kind.
+func (r *Roots) Virtual(u uri.URI) (uri.URI, bool) {
+	kind, root := r.Classify(u)
+	var scheme string
+	switch kind {
+	case KindGOROOT:
+		scheme = SchemeGOROOT
+	case KindGOMODCACHE:
+		scheme = SchemeGOMODCACHE
+	default:
+		return "", false
+	}
+	rel, _ := within(u, root)
+	c := u.Components()
+	v, err := uri.From(uri.Components{Scheme: scheme, Path: "/" + rel, Query: c.Query, Fragment: c.Fragment})
+	if err != nil {
+		return "", false
+	}
+	return v, true
+}
+
+// Concrete rewrites a goroot: or gomodcache: URI to the file URI under the
+// matching root. Query and fragment are kept. It reports false for other
+// schemes, for a missing root, and for virtual paths that are not rooted or
+// that contain ".." segments and could therefore escape the root.
+func (r *Roots) Concrete(u uri.URI) (uri.URI, bool) {
+	var root uri.URI
+	switch u.Scheme() {
+	case SchemeGOROOT:
+		root = r.GOROOT
+	case SchemeGOMODCACHE:
+		root = r.GOMODCACHE
+	default:
+		return "", false
+	}
+	c := u.Components()
+	if root.IsZero() || c.Authority != "" || !strings.HasPrefix(c.Path, "/") {
+		return "", false
+	}
+	rel := c.Path[1:]
+	for segment := range strings.SplitSeq(rel, "/") {
+		if segment == ".." {
+			return "", false
+		}
+	}
+	path := strings.TrimSuffix(root.Path(), "/")
+	if rel != "" {
+		path += "/" + rel
+	}
+	v, err := root.With(uri.Change{Path: &path, Query: &c.Query, Fragment: &c.Fragment})
+	if err != nil {
+		return "", false
+	}
+	return v, true
+}
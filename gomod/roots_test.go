@@ -0,0 +1,181 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gomod
+
+import (
+	"testing"
+
+	"go.lsp.dev/uri"
+)
+
+func testRoots() *Roots {
+	return &Roots{
+		GOROOT:     uri.FileFor(uri.PlatformPOSIX, "/usr/local/go"),
+		GOMODCACHE: uri.FileFor(uri.PlatformPOSIX, "/home/me/go/pkg/mod"),
+		GOPATH:     []uri.URI{uri.FileFor(uri.PlatformPOSIX, "/home/me/go")},
+		Workspace:  []uri.URI{uri.FileFor(uri.PlatformPOSIX, "/home/me/go/src/example.com/app")},
+	}
+}
+
+func TestRootsClassify(t *testing.T) {
+	tests := map[string]struct {
+		uri      string
+		wantKind Kind
+		wantRoot string
+	}{
+		"success: standard library": {
+			uri:      "file:///usr/local/go/src/net/http/server.go",
+			wantKind: KindGOROOT,
+			wantRoot: "file:///usr/local/go",
+		},
+		"success: module cache wins over enclosing GOPATH": {
+			uri:      "file:///home/me/go/pkg/mod/example.com/mod%40v1.2.3/file.go",
+			wantKind: KindGOMODCACHE,
+			wantRoot: "file:///home/me/go/pkg/mod",
+		},
+		"success: workspace wins over enclosing GOPATH": {
+			uri:      "file:///home/me/go/src/example.com/app/main.go",
+			wantKind: KindWorkspace,
+			wantRoot: "file:///home/me/go/src/example.com/app",
+		},
+		"success: GOPATH": {
+			uri:      "file:///home/me/go/src/example.com/lib/lib.go",
+			wantKind: KindGOPATH,
+			wantRoot: "file:///home/me/go",
+		},
+		"success: root directory itself": {
+			uri:      "file:///usr/local/go",
+			wantKind: KindGOROOT,
+			wantRoot: "file:///usr/local/go",
+		},
+		"success: prefix without separator is other": {
+			uri:      "file:///usr/local/gopher/x.go",
+			wantKind: KindOther,
+		},
+		"success: non-file scheme is other": {
+			uri:      "untitled:untitled-1",
+			wantKind: KindOther,
+		},
+	}
+	roots := testRoots()
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			kind, root := roots.Classify(uri.MustParse(tt.uri))
+			if kind != tt.wantKind {
+				t.Fatalf("Classify() kind = %v, want %v", kind, tt.wantKind)
+			}
+			if root.String() != tt.wantRoot {
+				t.Fatalf("Classify() root = %q, want %q", root.String(), tt.wantRoot)
+			}
+		})
+	}
+}
+
+func TestRootsVirtualConcrete(t *testing.T) {
+	tests := map[string]struct {
+		concrete string
+		virtual  string
+	}{
+		"success: standard library file": {
+			concrete: "file:///usr/local/go/src/net/http/server.go",
+			virtual:  "goroot:/src/net/http/server.go",
+		},
+		"success: GOROOT itself": {
+			concrete: "file:///usr/local/go",
+			virtual:  "goroot:/",
+		},
+		"success: module cache file keeps escapes": {
+			concrete: "file:///home/me/go/pkg/mod/example.com/mod%40v1.2.3/file.go",
+			virtual:  "gomodcache:/example.com/mod%40v1.2.3/file.go",
+		},
+		"success: fragment is kept": {
+			concrete: "file:///usr/local/go/src/fmt/print.go#L10",
+			virtual:  "goroot:/src/fmt/print.go#L10",
+		},
+	}
+	roots := testRoots()
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			v, ok := roots.Virtual(uri.MustParse(tt.concrete))
+			if !ok || v.String() != tt.virtual {
+				t.Fatalf("Virtual() = (%q, %t), want (%q, true)", v.String(), ok, tt.virtual)
+			}
+			c, ok := roots.Concrete(v)
+			if !ok || c.String() != tt.concrete {
+				t.Fatalf("Concrete() = (%q, %t), want (%q, true)", c.String(), ok, tt.concrete)
+			}
+		})
+	}
+}
+
+func TestRootsPortableAcrossGOROOTs(t *testing.T) {
+	t.Parallel()
+
+	server := &Roots{GOROOT: uri.FileFor(uri.PlatformPOSIX, "/usr/local/go")}
+	client := &Roots{GOROOT: uri.FileFor(uri.PlatformWindows, `C:\Program Files\Go`)}
+	v, ok := server.Virtual(uri.MustParse("file:///usr/local/go/src/net/http/server.go"))
+	if !ok {
+		t.Fatal("Virtual() reported false")
+	}
+	got, ok := client.Concrete(v)
+	if !ok {
+		t.Fatal("Concrete() reported false")
+	}
+	const want = "file:///c%3A/Program%20Files/Go/src/net/http/server.go"
+	if got.String() != want {
+		t.Fatalf("Concrete() = %q, want %q", got.String(), want)
+	}
+}
+
+func TestRootsRejects(t *testing.T) {
+	tests := map[string]struct {
+		roots   *Roots
+		uri     string
+		virtual bool
+	}{
+		"error: workspace file has no virtual form": {
+			roots:   testRoots(),
+			uri:     "file:///home/me/go/src/example.com/app/main.go",
+			virtual: true,
+		},
+		"error: GOPATH file has no virtual form": {
+			roots:   testRoots(),
+			uri:     "file:///home/me/go/src/example.com/lib/lib.go",
+			virtual: true,
+		},
+		"error: dot dot segment escapes root": {
+			roots: testRoots(),
+			uri:   "goroot:/src/../../etc/passwd",
+		},
+		"error: missing root": {
+			roots: &Roots{},
+			uri:   "gomodcache:/example.com/x%40v1.0.0/x.go",
+		},
+		"error: relative virtual path": {
+			roots: testRoots(),
+			uri:   "goroot:src/fmt/print.go",
+		},
+		"error: other scheme": {
+			roots: testRoots(),
+			uri:   "file:///usr/local/go/src/fmt/print.go",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var ok bool
+			if tt.virtual {
+				_, ok = tt.roots.Virtual(uri.MustParse(tt.uri))
+			} else {
+				_, ok = tt.roots.Concrete(uri.MustParse(tt.uri))
+			}
+			if ok {
+				t.Fatal("reported true, want false")
+			}
+		})
+	}
+}
@@ -16,15 +16,16 @@ const (
 	classAuthorityExtra
 	classAlnum
 	classHex
+	classSubDelim
 )
 
 func main() {
 	var classes [256]uint8
 	for b := byte('a'); b <= byte('z'); b++ {
-		classes[b] |= classUnreserved | classAlnum | classHex
+		classes[b] |= classUnreserved | classAlnum
 	}
 	for b := byte('A'); b <= byte('Z'); b++ {
-		classes[b] |= classUnreserved | classAlnum | classHex
+		classes[b] |= classUnreserved | classAlnum
 	}
 	for b := byte('0'); b <= byte('9'); b++ {
 		classes[b] |= classUnreserved | classAlnum | classHex
@@ -36,6 +37,9 @@ func main() {
 	for _, b := range []byte{'[', ']', ':'} {
 		classes[b] |= classAuthorityExtra
 	}
+	for _, b := range []byte("!$&'()*+,;=") {
+		classes[b] |= classSubDelim
+	}
 	for _, b := range []byte("ABCDEFabcdef") {
 		classes[b] |= classHex
 	}
@@ -71,6 +75,7 @@ func main() {
 	fmt.Printf("\tcharClassAuthorityExtra uint8 = %d\n", classAuthorityExtra)
 	fmt.Printf("\tcharClassAlnum uint8 = %d\n", classAlnum)
 	fmt.Printf("\tcharClassHex uint8 = %d\n", classHex)
+	fmt.Printf("\tcharClassSubDelim uint8 = %d\n", classSubDelim)
 	fmt.Println(")")
 	fmt.Println()
 	printByteTable("uriCharClass", &classes)
@@ -78,6 +83,41 @@ func main() {
 	printByteTable("hexDecodeTable", &hex)
 	fmt.Println()
 	fmt.Printf("const percentTriplets = %q\n", pct.String())
+	fmt.Println()
+	printSWARFunc("swarUnreserved", "unreserved", &classes, classUnreserved)
+	fmt.Println()
+	printSWARFunc("swarPathPass", "unreserved or '/'", &classes, classUnreserved|classPathExtra)
+}
+
+// printSWARFunc prints a function that sets the high bit of every byte of an
+// all-ASCII word whose class has a bit of mask set. Each contiguous range
+// lo..hi of matching bytes becomes (w+(0x80-lo))&^(w+(0x7f-hi)) applied to
+// all eight bytes at once; with the high bits of w clear no byte carries into
+// its neighbour.
+func printSWARFunc(name, desc string, classes *[256]uint8, mask uint8) {
+	const ones = 0x0101010101010101
+	fmt.Printf("// %s sets the high bit of each byte of w that is %s.\n", name, desc)
+	fmt.Println("// Every byte of w must be ASCII.")
+	fmt.Printf("func %s(w uint64) uint64 {\n", name)
+	fmt.Print("\treturn ")
+	first := true
+	for lo := 0; lo < 0x80; lo++ {
+		if classes[lo]&mask == 0 {
+			continue
+		}
+		hi := lo
+		for hi+1 < 0x80 && classes[hi+1]&mask != 0 {
+			hi++
+		}
+		if !first {
+			fmt.Print(" |\n\t\t")
+		}
+		first = false
+		fmt.Printf("(w+%#016x)&^(w+%#016x)", uint64(0x80-lo)*ones, uint64(0x7f-hi)*ones)
+		lo = hi
+	}
+	fmt.Println()
+	fmt.Println("}")
 }
 
 func printByteTable(name string, values *[256]uint8) {
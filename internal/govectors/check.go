@@ -0,0 +1,383 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"go.lsp.dev/uri"
+	"go.lsp.dev/uri/uritest"
+)
+
+// report collects the outcome of checking a vector file.
+type report struct {
+	// fill reports whether fields missing from vectors are filled in.
+	fill bool
+	// problems are inconsistencies within the file.
+	problems []string
+	// changes are recorded or missing values that differ from the Go
+	// implementation.
+	changes []string
+	// filled names the vectors whose missing fields were filled in.
+	filled []string
+}
+
+func (r *report) problem(section, name, format string, args ...any) {
+	r.problems = append(r.problems, fmt.Sprintf("%s/%s: ", section, name)+fmt.Sprintf(format, args...))
+}
+
+// sections lists the vector sections in file order.
+var sections = []string{"parse", "errors", "paths", "with", "from", "file", "notebookCells", "parseOptions"}
+
+func (r *report) checkFile(v *vectorFile) {
+	var filled []string
+	note := func(section string, n int) {
+		if n > 0 {
+			filled = append(filled, section)
+		}
+	}
+	note("parse", checkSection(r, v, "parse", v.Parse, func(v *uritest.ParseVector) string { return v.Name }, r.deriveParse))
+	note("errors", checkSection(r, v, "errors", v.Errors, func(v *uritest.ErrorVector) string { return v.Name }, r.deriveError))
+	note("paths", checkSection(r, v, "paths", v.Paths, func(v *uritest.PathVector) string { return v.Name }, r.derivePath))
+	note("with", checkSection(r, v, "with", v.With, func(v *uritest.WithVector) string { return v.Name }, r.deriveWith))
+	note("from", checkSection(r, v, "from", v.From, func(v *uritest.FromVector) string { return v.Name }, r.deriveFrom))
+	note("file", checkSection(r, v, "file", v.File, func(v *uritest.FileVector) string { return v.Name }, r.deriveFile))
+	note("notebookCells", checkSection(r, v, "notebookCells", v.NotebookCells, func(v *uritest.CellVector) string { return v.Name }, r.deriveCell))
+	note("parseOptions", checkSection(r, v, "parseOptions", v.ParseOptions, func(v *uritest.OptionVector) string { return v.Name }, r.deriveOption))
+
+	listed := make(map[string]bool)
+	for _, s := range v.ReferenceGenerated {
+		section, _, _ := strings.Cut(s, ".")
+		listed[section] = true
+	}
+	for _, s := range v.Curated {
+		listed[s] = true
+	}
+	for _, section := range sections {
+		if !listed[section] {
+			r.problems = append(r.problems, fmt.Sprintf("%s is listed as neither referenceGenerated nor curated", section))
+		}
+	}
+	// Values derived here were not confirmed by vscode-uri; the next
+	// reference regeneration does that and drops them from curated.
+	for _, section := range filled {
+		if !slices.Contains(v.Curated, section) {
+			v.Curated = append(v.Curated, section)
+		}
+	}
+}
+
+// optional lists the fields a vector of each section omits when they are
+// zero: the segments of a path operation without any, and results that
+// exclude each other.
+var optional = map[string][]string{
+	"errors":       {"error"},
+	"paths":        {"segments"},
+	"with":         {"want", "throws"},
+	"from":         {"want", "throws"},
+	"parseOptions": {"string", "error"},
+}
+
+// checkSection compares each vector with the one derive returns for it,
+// reporting differences and filling in missing fields, and returns the number
+// of vectors filled in.
+func checkSection[V any](r *report, f *vectorFile, section string, vectors []V, name func(*V) string, derive func(*V, *record) (V, bool)) int {
+	seen := make(map[string]bool)
+	filled := 0
+	zero := fields(new(V))
+	for i := range vectors {
+		v := &vectors[i]
+		n := name(v)
+		if seen[n] {
+			r.problem(section, n, "duplicate name")
+		}
+		seen[n] = true
+
+		rec := f.record(section, i, v)
+		want, ok := derive(v, rec)
+		if !ok {
+			continue
+		}
+		got, derived := rec.fields(v), fields(&want)
+		for _, key := range optional[section] {
+			if bytes.Equal(derived[key], zero[key]) {
+				delete(derived, key)
+			}
+		}
+		missing := make(map[string]json.RawMessage)
+		for _, d := range diffFields(got, derived) {
+			if d.a == nil && r.fill {
+				missing[d.key] = d.b
+				continue
+			}
+			r.changes = append(r.changes, fmt.Sprintf("%s/%s: %s: %s -> %s", section, n, d.key, showField(d.a), showField(d.b)))
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		data, err := json.Marshal(missing)
+		if err == nil {
+			err = json.Unmarshal(data, v)
+		}
+		if err != nil {
+			r.problem(section, n, "filling %v: %v", slices.Sorted(maps.Keys(missing)), err)
+			continue
+		}
+		for key, value := range missing {
+			rec.raw[key] = value
+			rec.decoded[key] = value
+		}
+		r.filled = append(r.filled, fmt.Sprintf("%s/%s: %s", section, n, strings.Join(slices.Sorted(maps.Keys(missing)), ", ")))
+		filled++
+	}
+	return filled
+}
+
+// fieldDiff is a JSON field whose value differs between two vectors; a nil
+// value means the field is absent.
+type fieldDiff struct {
+	key  string
+	a, b json.RawMessage
+}
+
+func diffFields(a, b map[string]json.RawMessage) []fieldDiff {
+	var keys []string
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	var diffs []fieldDiff
+	for _, k := range keys {
+		if !bytes.Equal(a[k], b[k]) {
+			diffs = append(diffs, fieldDiff{key: k, a: a[k], b: b[k]})
+		}
+	}
+	return diffs
+}
+
+func showField(v json.RawMessage) string {
+	if v == nil {
+		return "(none)"
+	}
+	return string(v)
+}
+
+func (r *report) deriveParse(v *uritest.ParseVector, rec *record) (uritest.ParseVector, bool) {
+	u, err := uri.Parse(v.Input)
+	if err != nil {
+		r.problem("parse", v.Name, "Parse(%q) error = %v", v.Input, err)
+		return uritest.ParseVector{}, false
+	}
+	if rec.has("string") {
+		// Components and filesystem paths are recorded from reparsing the
+		// canonical string, not from the input.
+		c, err := uri.Parse(v.String)
+		switch {
+		case err != nil:
+			r.problem("parse", v.Name, "string %q does not parse: %v", v.String, err)
+		case c.String() != v.String:
+			r.problem("parse", v.Name, "string %q is not canonical; it reparses to %q", v.String, c.String())
+		default:
+			reparsed := parseVectorFor(v, c)
+			for _, d := range diffFields(rec.fields(v), rec.fields(&reparsed)) {
+				r.problem("parse", v.Name, "%s %s does not match reparsing string %q, which gives %s", d.key, d.a, v.String, d.b)
+			}
+		}
+	}
+	if rec.has("fsPathPOSIX") && rec.has("fsPathWindows") && strings.ReplaceAll(v.FsPathPOSIX, "/", `\`) != v.FsPathWindows {
+		r.problem("parse", v.Name, "fsPathWindows %q is not fsPathPOSIX %q with backslashes", v.FsPathWindows, v.FsPathPOSIX)
+	}
+	return parseVectorFor(v, u), true
+}
+
+// parseVectorFor returns v with the fields the Go implementation derives from
+// u.
+func parseVectorFor(v *uritest.ParseVector, u uri.URI) uritest.ParseVector {
+	return uritest.ParseVector{
+		Name:             v.Name,
+		Input:            v.Input,
+		Components:       u.Components(),
+		String:           u.String(),
+		StringNoEncoding: u.StringNoEncoding(),
+		FsPathPOSIX:      uri.FsPathFor(u, uri.PlatformPOSIX, false),
+		FsPathWindows:    uri.FsPathFor(u, uri.PlatformWindows, false),
+	}
+}
+
+func (r *report) deriveError(v *uritest.ErrorVector, _ *record) (uritest.ErrorVector, bool) {
+	parse := uri.Parse
+	if v.Strict {
+		parse = uri.ParseStrict
+	}
+	d := *v
+	d.Error = ""
+	if _, err := parse(v.Input); err != nil {
+		d.Error = sentinelText(err)
+	}
+	return d, true
+}
+
+func (r *report) derivePath(v *uritest.PathVector, _ *record) (uritest.PathVector, bool) {
+	u, err := uri.Parse(v.URI)
+	if err != nil {
+		r.problem("paths", v.Name, "Parse(%q) error = %v", v.URI, err)
+		return uritest.PathVector{}, false
+	}
+	var got string
+	switch v.Op {
+	case "join", "resolve":
+		fn := uri.JoinPath
+		if v.Op == "resolve" {
+			fn = uri.ResolvePath
+		}
+		w, err := fn(u, v.Segments...)
+		if err != nil {
+			r.problem("paths", v.Name, "%s error = %v", v.Op, err)
+			return uritest.PathVector{}, false
+		}
+		got = w.String()
+	case "dirname":
+		got = uri.Dirname(u).String()
+	case "basename":
+		got = uri.Basename(u)
+	case "extname":
+		got = uri.Extname(u)
+	default:
+		r.problem("paths", v.Name, "unknown op %q", v.Op)
+		return uritest.PathVector{}, false
+	}
+	d := *v
+	d.Want = got
+	return d, true
+}
+
+func (r *report) deriveWith(v *uritest.WithVector, rec *record) (uritest.WithVector, bool) {
+	if rec.has("want") && rec.has("throws") {
+		r.problem("with", v.Name, "records both want and throws")
+	}
+	u, err := uri.Parse(v.URI)
+	if err != nil {
+		r.problem("with", v.Name, "Parse(%q) error = %v", v.URI, err)
+		return uritest.WithVector{}, false
+	}
+	d := *v
+	d.Want, d.Throws = result(u.With(v.Change))
+	return d, true
+}
+
+func (r *report) deriveFrom(v *uritest.FromVector, rec *record) (uritest.FromVector, bool) {
+	if rec.has("want") && rec.has("throws") {
+		r.problem("from", v.Name, "records both want and throws")
+	}
+	d := *v
+	d.Want, d.Throws = result(uri.From(v.Components))
+	return d, true
+}
+
+// result returns the want and throws fields for the outcome of With or From.
+func result(u uri.URI, err error) (string, bool) {
+	if err != nil {
+		return "", true
+	}
+	return u.String(), false
+}
+
+func (r *report) deriveFile(v *uritest.FileVector, _ *record) (d uritest.FileVector, ok bool) {
+	var platform uri.Platform
+	switch v.Platform {
+	case "posix":
+		platform = uri.PlatformPOSIX
+	case "windows":
+		platform = uri.PlatformWindows
+	default:
+		r.problem("file", v.Name, "unknown platform %q", v.Platform)
+		return uritest.FileVector{}, false
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			r.problem("file", v.Name, "FileFor(%q) panics: %v", v.Path, e)
+			d, ok = uritest.FileVector{}, false
+		}
+	}()
+	d = *v
+	d.Want = uri.FileFor(platform, v.Path).String()
+	return d, true
+}
+
+func (r *report) deriveCell(v *uritest.CellVector, rec *record) (uritest.CellVector, bool) {
+	notebook, err := uri.Parse(v.Notebook)
+	if err != nil {
+		r.problem("notebookCells", v.Name, "Parse(%q) error = %v", v.Notebook, err)
+		return uritest.CellVector{}, false
+	}
+	if rec.has("cell") {
+		cell, err := uri.Parse(v.Cell)
+		if err != nil {
+			r.problem("notebookCells", v.Name, "Parse(%q) error = %v", v.Cell, err)
+		} else if nb, handle, ok := uri.ParseNotebookCell(cell); !ok || nb != notebook || handle != v.Handle {
+			r.problem("notebookCells", v.Name, "cell %q parses back to (%q, %d, %t), want (%q, %d, true)",
+				v.Cell, nb, handle, ok, notebook, v.Handle)
+		}
+	}
+	d := *v
+	d.Cell = uri.NotebookCell(notebook, v.Handle).String()
+	return d, true
+}
+
+func (r *report) deriveOption(v *uritest.OptionVector, rec *record) (uritest.OptionVector, bool) {
+	if rec.has("string") && rec.has("error") {
+		r.problem("parseOptions", v.Name, "records both string and error")
+	}
+	d := *v
+	d.String, d.Error = "", ""
+	if u, err := uri.NewParser(uri.ParseOptions(v.Options)).Parse(v.Input); err != nil {
+		d.Error = sentinelText(err)
+	} else {
+		d.String = u.String()
+	}
+	return d, true
+}
+
+// sentinels are the errors that vectors name by their text.
+var sentinels = []error{
+	uri.ErrMissingScheme,
+	uri.ErrInvalidScheme,
+	uri.ErrAuthorityPath,
+	uri.ErrPathAuthority,
+	uri.ErrNotDataURI,
+	uri.ErrInvalidDataURI,
+	uri.ErrInvalidCharacter,
+	uri.ErrInvalidPercentEncoding,
+	uri.ErrInvalidHost,
+	uri.ErrInvalidPort,
+	uri.ErrDrivePath,
+	uri.ErrUserinfo,
+	uri.ErrTooLong,
+	uri.ErrTooComplex,
+	uri.ErrControlCharacter,
+	uri.ErrSchemeNotAllowed,
+}
+
+// sentinelText returns the text of the sentinel error err wraps, or the text
+// of err itself if it wraps none.
+func sentinelText(err error) string {
+	for _, s := range sentinels {
+		if errors.Is(err, s) {
+			return s.Error()
+		}
+	}
+	return err.Error()
+}
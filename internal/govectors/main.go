@@ -0,0 +1,192 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command govectors maintains uritest/vectors.json without Node.js.
+//
+// The reference for the vectors is vscode-uri, run by tools/genvectors. Where
+// that is not possible, govectors re-derives every field the Go
+// implementation can produce and checks the file's internal consistency:
+// parse vectors must follow the canonical reparse contract, fsPathWindows
+// must be fsPathPOSIX with backslashes, notebook cells must round trip, and
+// every section must be listed as reference generated or curated.
+//
+// Usage:
+//
+//	go run ./internal/govectors [-check] [-vectors uritest/vectors.json]
+//
+// By default govectors fills in the fields of vectors that do not record them
+// yet, such as a parse vector added with only a name and an input, and lists
+// their sections under "curated" so the next reference regeneration confirms
+// them. It never replaces a recorded value. With -check it writes nothing and
+// reports every vector that would change if the Go implementation were the
+// reference.
+//
+// The exit status is 1 if the file is inconsistent or any recorded value
+// differs from the Go implementation, and 2 for usage errors.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"go.lsp.dev/uri/uritest"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("govectors", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	check := fs.Bool("check", false, "report vectors that would change without writing the file")
+	name := fs.String("vectors", "uritest/vectors.json", "vector file to check or update")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "govectors: unexpected arguments %q\n", fs.Args())
+		return 2
+	}
+
+	data, err := os.ReadFile(*name)
+	if err != nil {
+		fmt.Fprintf(stderr, "govectors: %v\n", err)
+		return 1
+	}
+	vectors, err := decode(data)
+	if err != nil {
+		fmt.Fprintf(stderr, "govectors: %s: %v\n", *name, err)
+		return 1
+	}
+
+	r := &report{fill: !*check}
+	r.checkFile(vectors)
+	for _, line := range r.problems {
+		fmt.Fprintf(stderr, "%s: %s\n", *name, line)
+	}
+	for _, line := range r.changes {
+		fmt.Fprintf(stdout, "%s\n", line)
+	}
+	if len(r.problems) > 0 {
+		return 1
+	}
+
+	if len(r.filled) > 0 && !*check {
+		for _, line := range r.filled {
+			fmt.Fprintf(stdout, "filled %s\n", line)
+		}
+		out, err := encode(vectors)
+		if err == nil {
+			err = os.WriteFile(*name, out, 0o644)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "govectors: %v\n", err)
+			return 1
+		}
+	}
+	if len(r.changes) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// decode decodes a vector file with uritest.DecodeVectors, rejecting
+// sections and fields this program does not know, and records the fields
+// each vector has.
+func decode(data []byte) (*vectorFile, error) {
+	vectors, err := uritest.DecodeVectors(data)
+	if err != nil {
+		return nil, err
+	}
+	var objects map[string]json.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, err
+	}
+	f := &vectorFile{Vectors: vectors, records: make(map[string][]*record)}
+	for _, section := range sections {
+		var raw []map[string]json.RawMessage
+		if err := json.Unmarshal(objects[section], &raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", section, err)
+		}
+		for i, v := range f.section(section) {
+			f.records[section] = append(f.records[section], &record{raw: raw[i], decoded: fields(v)})
+		}
+	}
+	return f, nil
+}
+
+// encode formats vectors the way JSON.stringify(vectors, null, 2) does in
+// tools/genvectors, writing each vector's recorded fields only.
+func encode(f *vectorFile) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, fl := range orderedFields(f.Vectors) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := marshal(fl.key)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		if !slices.Contains(sections, fl.key) {
+			b.Write(fl.value)
+			continue
+		}
+		b.WriteByte('[')
+		for j, v := range f.section(fl.key) {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			if err := encodeVector(&b, v, f.record(fl.key, j, v)); err != nil {
+				return nil, err
+			}
+		}
+		b.WriteByte(']')
+	}
+	b.WriteByte('}')
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, b.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// encodeVector writes the fields rec records for v, keeping a value as
+// written unless it was changed since decoding.
+func encodeVector(b *bytes.Buffer, v any, rec *record) error {
+	b.WriteByte('{')
+	n := 0
+	for _, fl := range orderedFields(v) {
+		value, ok := rec.raw[fl.key]
+		if !ok {
+			continue
+		}
+		if !bytes.Equal(fl.value, rec.decoded[fl.key]) {
+			value = fl.value
+		}
+		key, err := marshal(fl.key)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			b.WriteByte(',')
+		}
+		n++
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return nil
+}
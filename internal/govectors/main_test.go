@@ -0,0 +1,232 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.lsp.dev/uri"
+	"go.lsp.dev/uri/uritest"
+)
+
+const committedVectors = "../../uritest/vectors.json"
+
+func TestEncodeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile(committedVectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vectors, err := decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := encode(vectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("encode(decode(vectors.json)) differs from vectors.json")
+	}
+}
+
+func TestRunCheckCommitted(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr strings.Builder
+	if status := run([]string{"-check", "-vectors", committedVectors}, &stdout, &stderr); status != 0 {
+		t.Fatalf("run(-check) = %d, want 0\nstdout:\n%s\nstderr:\n%s", status, stdout.String(), stderr.String())
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		edit       func(v *vectorFile)
+		check      bool
+		wantStatus int
+		wantStdout []string
+		wantStderr []string
+		verify     func(t *testing.T, v *vectorFile)
+	}{
+		"success: fill missing fields": {
+			edit: func(v *vectorFile) {
+				v.Parse = append(v.Parse, uritest.ParseVector{Name: "new", Input: "file:///C:/new file.go"})
+				path := "//b"
+				v.With = append(v.With, uritest.WithVector{Name: "new", URI: "file:///a", Change: uri.Change{Path: &path}})
+			},
+			wantStdout: []string{
+				"filled parse/new: components, fsPathPOSIX, fsPathWindows, string, stringNoEncoding\n",
+				"filled with/new: throws\n",
+			},
+			verify: func(t *testing.T, v *vectorFile) {
+				p := v.Parse[len(v.Parse)-1]
+				if p.String != "file:///c%3A/new%20file.go" {
+					t.Fatalf("filled parse string = %q, want file:///c%%3A/new%%20file.go", p.String)
+				}
+				if p.FsPathWindows != `c:\new file.go` {
+					t.Fatalf("filled fsPathWindows = %q, want c:\\new file.go", p.FsPathWindows)
+				}
+				if i := len(v.With) - 1; !v.With[i].Throws || v.has("with", i, "want") {
+					t.Fatalf("filled with vector = %+v, want throws", v.With[i])
+				}
+				for _, section := range []string{"parse", "with"} {
+					if !slices.Contains(v.Curated, section) {
+						t.Fatalf("curated = %v, want %s", v.Curated, section)
+					}
+				}
+			},
+		},
+		"error: check reports missing fields": {
+			edit: func(v *vectorFile) {
+				v.File = append(v.File, uritest.FileVector{Name: "new", Platform: "windows", Path: `C:\x`})
+			},
+			check:      true,
+			wantStatus: 1,
+			wantStdout: []string{`file/new: want: (none) -> "file:///c%3A/x"` + "\n"},
+			verify: func(t *testing.T, v *vectorFile) {
+				if i := len(v.File) - 1; v.has("file", i, "want") {
+					t.Fatalf("check mode filled want = %q", v.File[i].Want)
+				}
+			},
+		},
+		"error: recorded value differs from Go": {
+			edit: func(v *vectorFile) {
+				v.Paths[0].Want = "foo://a/other"
+				v.Parse = append(v.Parse, uritest.ParseVector{Name: "new", Input: "file:///a"})
+			},
+			wantStatus: 1,
+			wantStdout: []string{
+				`paths/join append: want: "foo://a/other" -> "foo://a/foo/bar/x"` + "\n",
+				"filled parse/new:",
+			},
+		},
+		"error: string is not canonical": {
+			edit: func(v *vectorFile) {
+				v.Parse[0].String = "HTTP:/api/files/test.me?t=1234"
+			},
+			wantStatus: 1,
+			wantStderr: []string{`parse/http single slash query: string "HTTP:/api/files/test.me?t=1234" is not canonical`},
+		},
+		"error: components not from canonical reparse": {
+			edit: func(v *vectorFile) {
+				v.Parse[1].Components.Path = "/C:/test/me"
+			},
+			wantStatus: 1,
+			wantStderr: []string{`parse/file drive path: components`},
+		},
+		"error: fsPathWindows is not fsPathPOSIX with backslashes": {
+			edit: func(v *vectorFile) {
+				v.Parse[0].FsPathWindows = "/api/files/test.me"
+			},
+			wantStatus: 1,
+			wantStderr: []string{"fsPathWindows \"/api/files/test.me\" is not fsPathPOSIX"},
+		},
+		"error: notebook cell does not round trip": {
+			edit: func(v *vectorFile) {
+				v.NotebookCells[0].Handle++
+			},
+			wantStatus: 1,
+			wantStderr: []string{"notebookCells/file notebook first cell: cell"},
+		},
+		"error: section neither generated nor curated": {
+			edit: func(v *vectorFile) {
+				v.Curated = slices.DeleteFunc(v.Curated, func(s string) bool { return s == "errors" })
+			},
+			wantStatus: 1,
+			wantStderr: []string{"errors is listed as neither referenceGenerated nor curated"},
+		},
+		"error: duplicate name": {
+			edit: func(v *vectorFile) {
+				v.From = append(v.From, v.From[0])
+			},
+			wantStatus: 1,
+			wantStderr: []string{"from/file path is encoded: duplicate name"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := os.ReadFile(committedVectors)
+			if err != nil {
+				t.Fatal(err)
+			}
+			vectors, err := decode(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.edit(vectors)
+			data, err = encode(vectors)
+			if err != nil {
+				t.Fatal(err)
+			}
+			file := filepath.Join(t.TempDir(), "vectors.json")
+			if err := os.WriteFile(file, data, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			args := []string{"-vectors", file}
+			if tt.check {
+				args = append(args, "-check")
+			}
+			var stdout, stderr strings.Builder
+			status := run(args, &stdout, &stderr)
+			if status != tt.wantStatus {
+				t.Fatalf("run() = %d, want %d\nstdout:\n%s\nstderr:\n%s", status, tt.wantStatus, stdout.String(), stderr.String())
+			}
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Fatalf("run() stdout = %q, want it to contain %q", stdout.String(), want)
+				}
+			}
+			for _, want := range tt.wantStderr {
+				if !strings.Contains(stderr.String(), want) {
+					t.Fatalf("run() stderr = %q, want it to contain %q", stderr.String(), want)
+				}
+			}
+			if tt.verify == nil {
+				return
+			}
+			data, err = os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			written, err := decode(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.verify(t, written)
+		})
+	}
+}
+
+func TestRunRejectsUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "vectors.json")
+	if err := os.WriteFile(file, []byte(`{"version": 2, "bogus": []}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr strings.Builder
+	if status := run([]string{"-vectors", file}, &stdout, &stderr); status != 1 {
+		t.Fatalf("run() = %d, want 1", status)
+	}
+	if !strings.Contains(stderr.String(), "bogus") {
+		t.Fatalf("run() stderr = %q, want the unknown field named", stderr.String())
+	}
+}
+
+// has reports whether the vector at index i of section records key.
+func (f *vectorFile) has(section string, i int, key string) bool {
+	return f.record(section, i, f.section(section)[i]).has(key)
+}
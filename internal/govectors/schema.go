@@ -0,0 +1,153 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"go.lsp.dev/uri/uritest"
+)
+
+// vectorFile is a vector file decoded into the uritest types, together with
+// the fields each vector records. The uritest types cannot tell an absent
+// field from a zero one, but govectors fills in only absent fields and writes
+// recorded values back exactly as tools/genvectors wrote them.
+type vectorFile struct {
+	*uritest.Vectors
+
+	// records holds the record of each vector, by section and in section
+	// order. Vectors appended after decoding have none.
+	records map[string][]*record
+}
+
+// record is the JSON object of a vector as written in the file.
+type record struct {
+	// raw maps each recorded field to its value as written.
+	raw map[string]json.RawMessage
+	// decoded maps each field of the vector to its value when decoded, so
+	// that a recorded value changed since is written anew.
+	decoded map[string]json.RawMessage
+}
+
+// has reports whether the vector records key.
+func (r *record) has(key string) bool {
+	_, ok := r.raw[key]
+	return ok
+}
+
+// fields returns the JSON fields of v that the vector records.
+func (r *record) fields(v any) map[string]json.RawMessage {
+	m := fields(v)
+	for k := range m {
+		if !r.has(k) {
+			delete(m, k)
+		}
+	}
+	return m
+}
+
+// section returns pointers to the vectors of the named section.
+func (f *vectorFile) section(name string) []any {
+	switch name {
+	case "parse":
+		return pointers(f.Parse)
+	case "errors":
+		return pointers(f.Errors)
+	case "paths":
+		return pointers(f.Paths)
+	case "with":
+		return pointers(f.With)
+	case "from":
+		return pointers(f.From)
+	case "file":
+		return pointers(f.File)
+	case "notebookCells":
+		return pointers(f.NotebookCells)
+	case "parseOptions":
+		return pointers(f.ParseOptions)
+	}
+	panic("govectors: unknown section " + name)
+}
+
+func pointers[V any](vectors []V) []any {
+	p := make([]any, len(vectors))
+	for i := range vectors {
+		p[i] = &vectors[i]
+	}
+	return p
+}
+
+// record returns the record of v, the vector at index i of section. A vector
+// appended after decoding records its non-zero fields.
+func (f *vectorFile) record(section string, i int, v any) *record {
+	records := f.records[section]
+	if i < len(records) {
+		return records[i]
+	}
+	rec := &record{raw: make(map[string]json.RawMessage), decoded: fields(v)}
+	zero := fields(reflect.New(reflect.TypeOf(v).Elem()).Interface())
+	for k, value := range rec.decoded {
+		if !bytes.Equal(value, zero[k]) {
+			rec.raw[k] = value
+		}
+	}
+	if i == len(records) {
+		f.records[section] = append(records, rec)
+	}
+	return rec
+}
+
+// field is a JSON object member.
+type field struct {
+	key   string
+	value json.RawMessage
+}
+
+// orderedFields returns the JSON fields of v in the order they are encoded.
+func orderedFields(v any) []field {
+	data, err := marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		panic(err)
+	}
+	var fs []field
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			panic(err)
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			panic(err)
+		}
+		fs = append(fs, field{key: key.(string), value: value})
+	}
+	return fs
+}
+
+// fields returns the JSON fields of v.
+func fields(v any) map[string]json.RawMessage {
+	m := make(map[string]json.RawMessage)
+	for _, f := range orderedFields(v) {
+		m[f.key] = f.value
+	}
+	return m
+}
+
+// marshal encodes v like JSON.stringify, without escaping HTML characters.
+func marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.27 && goexperiment.jsonv2
+
+package uri
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+)
+
+// MarshalJSONTo writes the canonical URI string as a JSON string token,
+// implementing json.MarshalerTo.
+func (u URI) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(string(u)))
+}
+
+// UnmarshalJSONFrom reads a JSON string and parses it like UnmarshalText,
+// implementing json.UnmarshalerFrom. A JSON null leaves u unchanged. Use
+// WithJSONParser to decode with other ParseOptions.
+func (u *URI) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, u, defaultParser)
+}
+
+// WithJSONParser returns json options that decode every URI with p instead
+// of the parser used by UnmarshalText, for example to require a scheme or to
+// enforce Limits on untrusted messages:
+//
+//	json.Unmarshal(data, &v, uri.WithJSONParser(uri.NewParser(uri.ParseOptions{Strict: true})))
+func WithJSONParser(p *Parser) json.Options {
+	return json.WithUnmarshalers(json.UnmarshalFromFunc(func(dec *jsontext.Decoder, u *URI) error {
+		return unmarshalJSONFrom(dec, u, p)
+	}))
+}
+
+func unmarshalJSONFrom(dec *jsontext.Decoder, u *URI, p *Parser) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	switch val.Kind() {
+	case 'n':
+		return nil
+	case '"':
+	default:
+		return fmt.Errorf("uri: cannot unmarshal JSON %s into URI", val.Kind())
+	}
+	text := val[1 : len(val)-1]
+	if bytes.IndexByte(text, '\\') >= 0 {
+		if text, err = jsontext.AppendUnquote(nil, val); err != nil {
+			return err
+		}
+	}
+	v, err := p.ParseBytes(text)
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
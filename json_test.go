@@ -0,0 +1,201 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.27 && goexperiment.jsonv2
+
+package uri
+
+import (
+	jsonv1 "encoding/json"
+	"encoding/json/v2"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONv2Marshaling(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"success: canonical JSON string": {
+			input: "https://host/p?name=ferret#f",
+			want:  `"https://host/p?name%3Dferret#f"`,
+		},
+		"success: file": {input: "file:///a b.go", want: `"file:///a%20b.go"`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u := MustParse(tt.input)
+			data, err := json.Marshal(u)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(data) != tt.want {
+				t.Fatalf("json.Marshal() = %s, want %s", data, tt.want)
+			}
+			v1, err := jsonv1.Marshal(u)
+			if err != nil || string(v1) != string(data) {
+				t.Fatalf("v1 json.Marshal() = %s, %v, want %s", v1, err, data)
+			}
+			var got URI
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got != u {
+				t.Fatalf("json.Unmarshal() = %q, want %q", got.String(), u.String())
+			}
+		})
+	}
+}
+
+func TestJSONv2Unmarshaling(t *testing.T) {
+	strict := WithJSONParser(NewParser(ParseOptions{Strict: true}))
+	tests := map[string]struct {
+		data    string
+		opts    []json.Options
+		want    URI
+		wantErr error
+	}{
+		"success: escaped JSON string": {
+			data: `"file:\/\/\/a b.go"`,
+			want: "file:///a%20b.go",
+		},
+		"success: null leaves value unchanged": {
+			data: `null`,
+			want: "untitled:keep",
+		},
+		"success: non-strict falls back to file": {
+			data: `"/a/b.go"`,
+			want: "file:///a/b.go",
+		},
+		"success: strict accepts scheme": {
+			data: `"file:///a/b.go"`,
+			opts: []json.Options{strict},
+			want: "file:///a/b.go",
+		},
+		"error: strict requires scheme": {
+			data:    `"/a/b.go"`,
+			opts:    []json.Options{strict},
+			wantErr: ErrMissingScheme,
+		},
+		"error: invalid scheme": {
+			data:    `"fäil:path"`,
+			wantErr: ErrInvalidScheme,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := URI("untitled:keep")
+			err := json.Unmarshal([]byte(tt.data), &got, tt.opts...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("json.Unmarshal() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("json.Unmarshal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// textURI routes a URI through encoding.TextMarshaler and
+// encoding.TextUnmarshaler only, the path json/v2 takes without the native
+// methods.
+type textURI URI
+
+func (u textURI) MarshalText() ([]byte, error) { return URI(u).MarshalText() }
+
+func (u *textURI) UnmarshalText(text []byte) error { return (*URI)(u).UnmarshalText(text) }
+
+func TestJSONv2Allocs(t *testing.T) {
+	data := []byte(`"file:///home/user/x.go"`)
+	u := MustParse("file:///home/user/x.go")
+	tu := textURI(u)
+	tests := map[string]struct {
+		native func()
+		text   func()
+	}{
+		"success: marshal": {
+			native: func() { _, _ = json.Marshal(u) },
+			text:   func() { _, _ = json.Marshal(tu) },
+		},
+		"success: unmarshal": {
+			native: func() {
+				var got URI
+				if err := json.Unmarshal(data, &got); err != nil || got != u {
+					t.Fatalf("json.Unmarshal() = %q, %v", got, err)
+				}
+			},
+			text: func() {
+				var got textURI
+				if err := json.Unmarshal(data, &got); err != nil || URI(got) != u {
+					t.Fatalf("json.Unmarshal() = %q, %v", got, err)
+				}
+			},
+		},
+	}
+	for name, tt := range tests {
+		native := testing.AllocsPerRun(1000, tt.native)
+		text := testing.AllocsPerRun(1000, tt.text)
+		if native > text {
+			t.Fatalf("%s: native allocs = %v, want <= %v text allocs", name, native, text)
+		}
+	}
+}
+
+func TestWithJSONParserLimits(t *testing.T) {
+	tests := map[string]struct {
+		limits  Limits
+		data    string
+		want    URI
+		wantErr error
+	}{
+		"success: zero limits decode anything": {
+			data: `"file:///` + strings.Repeat("a/", 64) + `"`,
+			want: URI("file:///" + strings.Repeat("a/", 64)),
+		},
+		"success: within limits": {
+			limits: Limits{MaxLength: 64, MaxPathSegments: 4},
+			data:   `"file:///a/b.go"`,
+			want:   "file:///a/b.go",
+		},
+		"error: oversized message field": {
+			limits:  Limits{MaxLength: 64},
+			data:    `"file:///` + strings.Repeat("a", 128) + `"`,
+			wantErr: ErrTooLong,
+		},
+		"error: too many triplets": {
+			limits:  Limits{MaxPercentTriplets: 2},
+			data:    `"foo:%41%42%43"`,
+			wantErr: ErrTooComplex,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var got URI
+			err := json.Unmarshal([]byte(tt.data), &got, WithJSONParser(NewParser(ParseOptions{Limits: tt.limits})))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("json.Unmarshal() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("json.Unmarshal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import "strings"
+
+// Limits bounds the size and complexity of input accepted from untrusted
+// sources. Zero fields are unlimited.
+//
+// Limits are checked on the raw input before any component is decoded, so
+// rejecting an oversized URI costs at most one scan of it.
+type Limits struct {
+	// MaxLength is the maximum input length in bytes.
+	MaxLength int `json:"maxLength"`
+	// MaxPathSegments is the maximum number of slash-separated path segments.
+	MaxPathSegments int `json:"maxPathSegments"`
+	// MaxAuthorityLength is the maximum raw authority length in bytes.
+	MaxAuthorityLength int `json:"maxAuthorityLength"`
+	// MaxPercentTriplets is the maximum number of '%' bytes, each of which
+	// starts a percent-encoded triplet the decoder has to examine.
+	MaxPercentTriplets int `json:"maxPercentTriplets"`
+}
+
+// checkLength reports ErrTooLong when s exceeds MaxLength.
+func (l *Limits) checkLength(s string) error {
+	if l.MaxLength > 0 && len(s) > l.MaxLength {
+		raw := splitRaw(s)
+		return uriErrorAt("parse", s, ErrTooLong, l.MaxLength, componentAt(&raw, l.MaxLength))
+	}
+	return nil
+}
+
+// check enforces the limits other than MaxLength on s split into raw.
+func (l *Limits) check(s string, raw *rawParts) error {
+	if l.MaxAuthorityLength > 0 && len(raw.authority) > l.MaxAuthorityLength {
+		return uriErrorAt("parse", s, ErrTooLong, raw.authorityStart+l.MaxAuthorityLength, ComponentAuthority)
+	}
+	if l.MaxPathSegments > 0 {
+		if i := nthPathSegment(raw.path, l.MaxPathSegments+1); i >= 0 {
+			return uriErrorAt("parse", s, ErrTooComplex, raw.pathStart+i, ComponentPath)
+		}
+	}
+	if l.MaxPercentTriplets > 0 {
+		if i := nthByte(s, '%', l.MaxPercentTriplets+1); i >= 0 {
+			return uriErrorAt("parse", s, ErrTooComplex, i, componentAt(raw, i))
+		}
+	}
+	return nil
+}
+
+// nthPathSegment returns the offset in path of the n-th (1-based) segment, or
+// -1 when path has fewer segments. A leading slash does not start an empty
+// first segment.
+func nthPathSegment(path string, n int) int {
+	if path == "" {
+		return -1
+	}
+	start := 0
+	if path[0] == '/' {
+		start = 1
+	}
+	if n == 1 {
+		return start
+	}
+	if i := nthByte(path[start:], '/', n-1); i >= 0 {
+		return start + i + 1
+	}
+	return -1
+}
+
+// nthByte returns the offset of the n-th (1-based) occurrence of c in s, or
+// -1 when there are fewer.
+func nthByte(s string, c byte, n int) int {
+	offset := 0
+	for {
+		i := strings.IndexByte(s[offset:], c)
+		if i < 0 {
+			return -1
+		}
+		n--
+		if n == 0 {
+			return offset + i
+		}
+		offset += i + 1
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLimitsPosition(t *testing.T) {
+	tests := map[string]struct {
+		input         string
+		limits        Limits
+		wantErr       error
+		wantOffset    int
+		wantComponent string
+	}{
+		"error: length points past the limit": {
+			input:         "foo:/abcdef",
+			limits:        Limits{MaxLength: 8},
+			wantErr:       ErrTooLong,
+			wantOffset:    8,
+			wantComponent: ComponentPath,
+		},
+		"error: authority points past the limit": {
+			input:         "foo://abcdef/x",
+			limits:        Limits{MaxAuthorityLength: 3},
+			wantErr:       ErrTooLong,
+			wantOffset:    9,
+			wantComponent: ComponentAuthority,
+		},
+		"error: first segment over the limit": {
+			input:         "foo:/a/bb/ccc",
+			limits:        Limits{MaxPathSegments: 2},
+			wantErr:       ErrTooComplex,
+			wantOffset:    10,
+			wantComponent: ComponentPath,
+		},
+		"error: relative path segments": {
+			input:         "foo:a/b",
+			limits:        Limits{MaxPathSegments: 1},
+			wantErr:       ErrTooComplex,
+			wantOffset:    6,
+			wantComponent: ComponentPath,
+		},
+		"error: first triplet over the limit in query": {
+			input:         "foo:/%41?%42",
+			limits:        Limits{MaxPercentTriplets: 1},
+			wantErr:       ErrTooComplex,
+			wantOffset:    9,
+			wantComponent: ComponentQuery,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewParser(ParseOptions{Limits: tt.limits}).Parse(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			var uerr *Error
+			if !errors.As(err, &uerr) {
+				t.Fatalf("Parse(%q) error = %T, want *Error", tt.input, err)
+			}
+			if uerr.Offset != tt.wantOffset || uerr.Component != tt.wantComponent {
+				t.Fatalf("Parse(%q) position = %d in %q, want %d in %q", tt.input, uerr.Offset, uerr.Component, tt.wantOffset, tt.wantComponent)
+			}
+		})
+	}
+}
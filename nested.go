@@ -0,0 +1,76 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	schemeJar = "jar"
+	schemeZip = "zip"
+
+	nestedSeparator = "!/"
+)
+
+// ParseNested splits a nested archive URI such as jar:file:///x.jar!/a/B.class
+// into the URI of the outer archive and the slash-separated entry inside it.
+// It reports false unless u has the jar or zip scheme.
+//
+// The decoded path of u holds the outer URI followed by the "!/" separator and
+// the entry. The last separator wins, so an archive inside an archive, such as
+// zip:jar:file:///x.jar!/inner.zip!/go.mod, yields the nested outer URI
+// jar:file:///x.jar!/inner.zip and the entry go.mod; call ParseNested again to
+// unwrap it. The outer URI is returned in canonical form.
+func ParseNested(u URI) (outer URI, entry string, ok bool) {
+	raw := splitRaw(string(u))
+	if !isNestedScheme(raw.scheme) || raw.authority != "" {
+		return "", "", false
+	}
+	path := percentDecode(raw.path)
+	idx := strings.LastIndex(path, nestedSeparator)
+	if idx < 0 {
+		return "", "", false
+	}
+	outer, err := ParseStrict(path[:idx])
+	if err != nil {
+		return "", "", false
+	}
+	return outer, path[idx+len(nestedSeparator):], true
+}
+
+// Nested returns the URI of entry inside the archive named by outer.
+//
+// Outer URIs whose path ends in .jar, .war, or .ear use the jar scheme and all
+// others use zip. The outer URI is embedded in its canonical encoded form, so
+// ParseNested(Nested(outer, entry)) returns outer and entry unchanged even
+// when outer is itself nested. A leading slash on entry is ignored.
+//
+// Nested panics if entry contains "!/". ParseNested splits at the last
+// separator, so such an entry could not be told apart from a nested archive.
+func Nested(outer URI, entry string) URI {
+	if strings.Contains(entry, nestedSeparator) {
+		panic("uri: nested entry " + strconv.Quote(entry) + " contains " + nestedSeparator)
+	}
+	scheme := schemeZip
+	switch Extname(outer) {
+	case ".jar", ".war", ".ear":
+		scheme = schemeJar
+	}
+	c := Components{
+		Scheme: scheme,
+		Path:   outer.String() + nestedSeparator + strings.TrimPrefix(entry, "/"),
+	}
+	u, err := newURI(&c, false, "nested", outer.String())
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func isNestedScheme(scheme string) bool {
+	return strings.EqualFold(scheme, schemeJar) || strings.EqualFold(scheme, schemeZip)
+}
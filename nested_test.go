@@ -0,0 +1,152 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNested(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		wantOuter string
+		wantEntry string
+		wantOK    bool
+	}{
+		"success: jar class entry": {
+			input:     "jar:file:///x.jar!/a/B.class",
+			wantOuter: "file:///x.jar",
+			wantEntry: "a/B.class",
+			wantOK:    true,
+		},
+		"success: zip go.mod entry": {
+			input:     "zip:file:///m.zip!/go.mod",
+			wantOuter: "file:///m.zip",
+			wantEntry: "go.mod",
+			wantOK:    true,
+		},
+		"success: canonical encoded form": {
+			input:     "jar:file%3A///x.jar%21/a/B.class",
+			wantOuter: "file:///x.jar",
+			wantEntry: "a/B.class",
+			wantOK:    true,
+		},
+		"success: outer is canonicalized": {
+			input:     "zip:file:///C:/mod%20cache/m.zip!/go.mod",
+			wantOuter: "file:///c%3A/mod%20cache/m.zip",
+			wantEntry: "go.mod",
+			wantOK:    true,
+		},
+		"success: archive inside archive splits at last separator": {
+			input:     "zip:jar:file:///x.jar!/inner.zip!/go.mod",
+			wantOuter: "jar:file%3A///x.jar%21/inner.zip",
+			wantEntry: "go.mod",
+			wantOK:    true,
+		},
+		"success: archive root": {
+			input:     "jar:file:///x.jar!/",
+			wantOuter: "file:///x.jar",
+			wantOK:    true,
+		},
+		"error: no separator": {input: "jar:file:///x.jar"},
+		"error: outer has no scheme": {
+			input: "jar:/x.jar!/a/B.class",
+		},
+		"error: not an archive scheme": {
+			input: "untitled:foo:bar!/x",
+		},
+		"error: authority is not nested form": {
+			input: "https://host/x.jar!/a",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			outer, entry, ok := ParseNested(MustParse(tt.input))
+			if ok != tt.wantOK {
+				t.Fatalf("ParseNested() ok = %t, want %t", ok, tt.wantOK)
+			}
+			if outer.String() != tt.wantOuter || entry != tt.wantEntry {
+				t.Fatalf("ParseNested() = (%q, %q), want (%q, %q)", outer.String(), entry, tt.wantOuter, tt.wantEntry)
+			}
+		})
+	}
+}
+
+func TestNested(t *testing.T) {
+	tests := map[string]struct {
+		outer string
+		entry string
+		want  string
+	}{
+		"success: jar entry": {
+			outer: "file:///x.jar",
+			entry: "a/B.class",
+			want:  "jar:file%3A///x.jar%21/a/B.class",
+		},
+		"success: zip entry drops leading slash": {
+			outer: "file:///m.zip",
+			entry: "/go.mod",
+			want:  "zip:file%3A///m.zip%21/go.mod",
+		},
+		"success: escaped outer is embedded canonically": {
+			outer: "file:///mod%20cache/m.zip",
+			entry: "go.mod",
+			want:  "zip:file%3A///mod%2520cache/m.zip%21/go.mod",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			outer := MustParse(tt.outer)
+			got := Nested(outer, tt.entry)
+			if got.String() != tt.want {
+				t.Fatalf("Nested() = %q, want %q", got.String(), tt.want)
+			}
+			if reparsed := MustParse(got.String()); reparsed != got {
+				t.Fatalf("Parse(Nested()) = %q, want canonical %q", reparsed.String(), got.String())
+			}
+			gotOuter, gotEntry, ok := ParseNested(got)
+			if !ok || gotOuter != outer || gotEntry != strings.TrimPrefix(tt.entry, "/") {
+				t.Fatalf("ParseNested(Nested()) = (%q, %q, %t), want (%q, %q, true)", gotOuter.String(), gotEntry, ok, outer.String(), tt.entry)
+			}
+		})
+	}
+}
+
+func TestNestedSeparatorInEntry(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Nested() with a separator in the entry did not panic")
+		}
+	}()
+	Nested(MustParse("file:///x.jar"), "a!/b")
+}
+
+func TestNestedRecursion(t *testing.T) {
+	t.Parallel()
+
+	jar := MustParse("file:///libs/x.jar")
+	inner := Nested(jar, "lib/inner.zip")
+	deep := Nested(inner, "pkg/go.mod")
+	if got, want := deep.String(), "zip:jar%3Afile%253A///libs/x.jar%2521/lib/inner.zip%21/pkg/go.mod"; got != want {
+		t.Fatalf("Nested(Nested()) = %q, want %q", got, want)
+	}
+
+	outer, entry, ok := ParseNested(deep)
+	if !ok || outer != inner || entry != "pkg/go.mod" {
+		t.Fatalf("ParseNested(deep) = (%q, %q, %t), want (%q, pkg/go.mod, true)", outer.String(), entry, ok, inner.String())
+	}
+	outer, entry, ok = ParseNested(outer)
+	if !ok || outer != jar || entry != "lib/inner.zip" {
+		t.Fatalf("ParseNested(inner) = (%q, %q, %t), want (%q, lib/inner.zip, true)", outer.String(), entry, ok, jar.String())
+	}
+	if _, _, ok := ParseNested(outer); ok {
+		t.Fatal("ParseNested(file URI) reported true")
+	}
+}
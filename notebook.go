@@ -0,0 +1,107 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+)
+
+// Notebook cell handles use VS Code's CellUri fragment layout: a length
+// marker, the handle in base 7, the letter s, and the notebook scheme in
+// padded URL-safe base64.
+const (
+	notebookCellRadix     = 7
+	notebookCellLengths   = "WXYZabcdef"
+	notebookCellLongMark  = 'z'
+	notebookCellSeparator = 's'
+)
+
+// NotebookCell returns the vscode-notebook-cell URI addressing the cell with
+// handle in notebook, matching VS Code's CellUri.generate.
+//
+// The notebook's scheme is moved into the fragment, which replaces any
+// notebook fragment; authority, path, and query are kept.
+func NotebookCell(notebook URI, handle int) URI {
+	s := strconv.FormatInt(int64(handle), notebookCellRadix)
+	mark := byte(notebookCellLongMark)
+	if len(s) < len(notebookCellLengths) {
+		mark = notebookCellLengths[len(s)-1]
+	}
+	fragment := string(mark) + s + string(notebookCellSeparator) + base64.URLEncoding.EncodeToString([]byte(notebook.Scheme()))
+	scheme := schemeNotebookCell
+	u, err := notebook.With(Change{Scheme: &scheme, Fragment: &fragment})
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// ParseNotebookCell returns the notebook URI and cell handle addressed by a
+// vscode-notebook-cell URI, matching VS Code's CellUri.parse.
+//
+// Like JavaScript's parseInt, the handle is read from the leading base-7
+// digits after the length marker; trailing characters before the separator are
+// ignored. The scheme is decoded from standard or URL-safe base64 with or
+// without padding.
+func ParseNotebookCell(u URI) (notebook URI, handle int, ok bool) {
+	if u.Scheme() != schemeNotebookCell {
+		return "", 0, false
+	}
+	fragment := u.Fragment()
+	idx := strings.IndexByte(fragment, notebookCellSeparator)
+	if idx < 0 {
+		return "", 0, false
+	}
+	handle, ok = parseNotebookCellHandle(strings.TrimLeft(fragment[:idx], notebookCellLengths))
+	if !ok {
+		return "", 0, false
+	}
+	scheme, ok := decodeNotebookCellScheme(fragment[idx+1:])
+	if !ok {
+		return "", 0, false
+	}
+	empty := ""
+	notebook, err := u.With(Change{Scheme: &scheme, Fragment: &empty})
+	if err != nil {
+		return "", 0, false
+	}
+	return notebook, handle, true
+}
+
+func parseNotebookCellHandle(s string) (int, bool) {
+	s = strings.TrimLeft(s, " \t\n\v\f\r")
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] < '0'+notebookCellRadix {
+		end++
+	}
+	if end == 0 {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(s[:end], notebookCellRadix, strconv.IntSize)
+	if err != nil {
+		return 0, false
+	}
+	if neg {
+		n = -n
+	}
+	return int(n), true
+}
+
+func decodeNotebookCellScheme(s string) (string, bool) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
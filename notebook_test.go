@@ -0,0 +1,115 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import "testing"
+
+func TestNotebookCell(t *testing.T) {
+	tests := map[string]struct {
+		notebook string
+		handle   int
+		want     string
+	}{
+		"success: first cell of file notebook": {
+			notebook: "file:///path/nb.ipynb",
+			handle:   0,
+			want:     "vscode-notebook-cell:/path/nb.ipynb#W0sZmlsZQ%3D%3D",
+		},
+		"success: two digit base 7 handle": {
+			notebook: "file:///path/nb.ipynb",
+			handle:   7,
+			want:     "vscode-notebook-cell:/path/nb.ipynb#X10sZmlsZQ%3D%3D",
+		},
+		"success: untitled notebook scheme": {
+			notebook: "untitled:Untitled-1.ipynb",
+			handle:   49,
+			want:     "vscode-notebook-cell:Untitled-1.ipynb#Y100sdW50aXRsZWQ%3D",
+		},
+		"success: authority and query kept, fragment replaced": {
+			notebook: "vscode-vfs://github/o/r/nb.ipynb?x=1#frag",
+			handle:   3,
+			want:     "vscode-notebook-cell://github/o/r/nb.ipynb?x%3D1#W3sdnNjb2RlLXZmcw%3D%3D",
+		},
+		"success: negative handle": {
+			notebook: "file:///nb.ipynb",
+			handle:   -1,
+			want:     "vscode-notebook-cell:/nb.ipynb#X-1sZmlsZQ%3D%3D",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := NotebookCell(MustParse(tt.notebook), tt.handle); got.String() != tt.want {
+				t.Fatalf("NotebookCell() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNotebookCell(t *testing.T) {
+	tests := map[string]struct {
+		cell         string
+		wantNotebook string
+		wantHandle   int
+		wantOK       bool
+	}{
+		"success: file notebook": {
+			cell:         "vscode-notebook-cell:/path/nb.ipynb#W0sZmlsZQ%3D%3D",
+			wantNotebook: "file:///path/nb.ipynb",
+			wantOK:       true,
+		},
+		"success: raw fragment and unpadded scheme": {
+			cell:         "vscode-notebook-cell:/path/nb.ipynb#X10sZmlsZQ",
+			wantNotebook: "file:///path/nb.ipynb",
+			wantHandle:   7,
+			wantOK:       true,
+		},
+		"success: standard base64 alphabet accepted": {
+			cell:         "vscode-notebook-cell://github/o/r/nb.ipynb#W3sdnNjb2RlLXZmcw==",
+			wantNotebook: "vscode-vfs://github/o/r/nb.ipynb",
+			wantHandle:   3,
+			wantOK:       true,
+		},
+		"success: parseInt ignores trailing junk": {
+			cell:         "vscode-notebook-cell:/nb.ipynb#W29sZmlsZQ",
+			wantNotebook: "file:///nb.ipynb",
+			wantHandle:   2,
+			wantOK:       true,
+		},
+		"success: empty scheme falls back to file": {
+			cell:         "vscode-notebook-cell:/nb.ipynb#W1s",
+			wantNotebook: "file:///nb.ipynb",
+			wantHandle:   1,
+			wantOK:       true,
+		},
+		"error: long handle marker is not stripped by VS Code": {
+			cell: "vscode-notebook-cell:/nb.ipynb#z3026236221sZmlsZQ%3D%3D",
+		},
+		"error: other scheme": {
+			cell: "file:///nb.ipynb#W0sZmlsZQ",
+		},
+		"error: missing separator": {
+			cell: "vscode-notebook-cell:/nb.ipynb#W0",
+		},
+		"error: no handle digits": {
+			cell: "vscode-notebook-cell:/nb.ipynb#WsZmlsZQ",
+		},
+		"error: invalid base64 scheme": {
+			cell: "vscode-notebook-cell:/nb.ipynb#W0s*",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			notebook, handle, ok := ParseNotebookCell(MustParse(tt.cell))
+			if ok != tt.wantOK {
+				t.Fatalf("ParseNotebookCell() ok = %t, want %t", ok, tt.wantOK)
+			}
+			if notebook.String() != tt.wantNotebook || handle != tt.wantHandle {
+				t.Fatalf("ParseNotebookCell() = (%q, %d), want (%q, %d)", notebook.String(), handle, tt.wantNotebook, tt.wantHandle)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+// Parsed is a view of a URI split into its components once.
+//
+// Each URI accessor splits the string again, so code that reads several
+// components of the same URI should call URI.Parsed and use the view instead.
+// Raw accessors return slices of the canonical string without allocating;
+// decoded accessors percent-decode on each call and allocate only when the
+// component contains escapes. Parsed is not comparable by component value; use
+// URI for equality and map keys.
+type Parsed struct {
+	uri URI
+	raw rawParts
+}
+
+// Parsed splits u into its components.
+func (u URI) Parsed() Parsed {
+	return Parsed{uri: u, raw: splitRaw(string(u))}
+}
+
+// URI returns the URI the view was split from.
+func (p Parsed) URI() URI {
+	return p.uri
+}
+
+// Scheme returns the URI scheme.
+func (p Parsed) Scheme() string {
+	return p.raw.scheme
+}
+
+// IsFile reports whether the URI has the exact file scheme.
+func (p Parsed) IsFile() bool {
+	return p.raw.scheme == schemeFile
+}
+
+// RawAuthority returns the authority as it appears in the canonical string.
+func (p Parsed) RawAuthority() string {
+	return p.raw.authority
+}
+
+// RawPath returns the path as it appears in the canonical string.
+func (p Parsed) RawPath() string {
+	return p.raw.path
+}
+
+// RawQuery returns the query as it appears in the canonical string, without
+// the leading '?'.
+func (p Parsed) RawQuery() string {
+	return p.raw.query
+}
+
+// RawFragment returns the fragment as it appears in the canonical string,
+// without the leading '#'.
+func (p Parsed) RawFragment() string {
+	return p.raw.fragment
+}
+
+// Authority returns the decoded authority. See URI.Authority.
+func (p Parsed) Authority() string {
+	return percentDecode(p.raw.authority)
+}
+
+// Path returns the decoded path. See URI.Path.
+func (p Parsed) Path() string {
+	return percentDecode(p.raw.path)
+}
+
+// Query returns the decoded query.
+func (p Parsed) Query() string {
+	return percentDecode(p.raw.query)
+}
+
+// Fragment returns the decoded fragment.
+func (p Parsed) Fragment() string {
+	return percentDecode(p.raw.fragment)
+}
+
+// Components returns all decoded URI components.
+func (p Parsed) Components() Components {
+	return Components{
+		Scheme:    p.raw.scheme,
+		Authority: p.Authority(),
+		Path:      p.Path(),
+		Query:     p.Query(),
+		Fragment:  p.Fragment(),
+	}
+}
+
+// FsPathFor returns FsPathFor(p.URI(), platform, keepDriveLetterCasing)
+// without splitting the URI again.
+func (p Parsed) FsPathFor(platform Platform, keepDriveLetterCasing bool) string {
+	if value, ok := fsPathFast(p.uri, platform, keepDriveLetterCasing); ok {
+		return value
+	}
+	return bytesString(appendFsPath(make([]byte, 0, len(p.uri)), &p.raw, platform, keepDriveLetterCasing))
+}
+
+// AppendFsPath appends FsPathFor(p.URI(), platform, false) to b and returns
+// the extended buffer. See URI.AppendFsPath.
+func (p Parsed) AppendFsPath(b []byte, platform Platform) []byte {
+	return appendFsPath(b, &p.raw, platform, false)
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"slices"
+	"strings"
+)
+
+// ParseOptions selects how a Parser treats input. The zero value parses like
+// Parse.
+type ParseOptions struct {
+	// Strict disables the vscode-uri fallback from an empty scheme to file and
+	// reports ErrMissingScheme instead, as ParseStrict does.
+	Strict bool
+	// DisallowDrivePath rejects Windows drive paths such as C:\dir or c:/dir,
+	// which vscode-uri otherwise reads as a URI with a one-letter scheme.
+	DisallowDrivePath bool
+	// DisallowUserinfo rejects authorities carrying a userinfo@ prefix.
+	DisallowUserinfo bool
+	// Limits bounds input size and complexity; failures wrap ErrTooLong or
+	// ErrTooComplex.
+	Limits Limits
+	// RejectControl rejects ASCII control bytes (0x00-0x1F and 0x7F) instead
+	// of percent-encoding them.
+	RejectControl bool
+	// Schemes, when not empty, lists the accepted schemes. Schemes compare
+	// case-insensitively against the scheme after the empty-scheme fallback.
+	Schemes []string
+	// RFC3986 additionally checks input against the RFC 3986 grammar as
+	// ParseRFC3986 does. A scheme is then required even without Strict.
+	RFC3986 bool
+}
+
+// Parser parses URIs with a fixed set of ParseOptions. A Parser is safe for
+// concurrent use.
+type Parser struct {
+	opts ParseOptions
+	// fileFast reports whether canonical file URIs may skip option checks.
+	fileFast bool
+}
+
+// NewParser returns a Parser for opts.
+func NewParser(opts ParseOptions) *Parser {
+	opts.Schemes = slices.Clone(opts.Schemes)
+	for i, scheme := range opts.Schemes {
+		opts.Schemes[i] = strings.ToLower(scheme)
+	}
+	return &Parser{
+		opts:     opts,
+		fileFast: opts.Limits.MaxPathSegments == 0 && (len(opts.Schemes) == 0 || slices.Contains(opts.Schemes, schemeFile)),
+	}
+}
+
+// Parse parses s according to the parser's options.
+//
+// Option failures are *Error values wrapping ErrDrivePath, ErrUserinfo,
+// ErrTooLong, ErrTooComplex, ErrControlCharacter, ErrSchemeNotAllowed, or an
+// RFC 3986 sentinel.
+func (p *Parser) Parse(s string) (URI, error) {
+	if err := p.opts.Limits.checkLength(s); err != nil {
+		return "", err
+	}
+	// Canonical absolute file URIs contain only unreserved bytes and slashes,
+	// so they pass every other option whenever file is an accepted scheme and
+	// path segments are not counted.
+	if p.fileFast {
+		if u, ok := parseCanonicalFileFast(s); ok {
+			return u, nil
+		}
+	}
+	raw := splitRaw(s)
+	if err := p.check(s, &raw); err != nil {
+		return "", err
+	}
+	return parseRaw(s, &raw, p.opts.Strict || p.opts.RFC3986)
+}
+
+func (p *Parser) check(s string, raw *rawParts) error {
+	if err := p.opts.Limits.check(s, raw); err != nil {
+		return err
+	}
+	if p.opts.RejectControl {
+		for i := 0; i < len(s); i++ {
+			if s[i] < 0x20 || s[i] == 0x7f {
+				return uriErrorAt("parse", s, ErrControlCharacter, i, componentAt(raw, i))
+			}
+		}
+	}
+	if p.opts.DisallowDrivePath && len(raw.scheme) == 1 && isAlpha(raw.scheme[0]) {
+		return uriErrorAt("parse", s, ErrDrivePath, 0, ComponentScheme)
+	}
+	if p.opts.DisallowUserinfo && strings.IndexByte(raw.authority, '@') >= 0 {
+		return uriErrorAt("parse", s, ErrUserinfo, raw.authorityStart, ComponentAuthority)
+	}
+	if p.opts.RFC3986 {
+		if err := validateRFC3986(s, raw); err != nil {
+			return err
+		}
+	}
+	if len(p.opts.Schemes) > 0 {
+		scheme := schemeFix(raw.scheme, p.opts.Strict)
+		if scheme != "" && !slices.Contains(p.opts.Schemes, strings.ToLower(scheme)) {
+			return uriErrorAt("parse", s, ErrSchemeNotAllowed, 0, ComponentScheme)
+		}
+	}
+	return nil
+}
+
+// componentAt names the component of the input split into raw that contains
+// the byte at offset i.
+func componentAt(raw *rawParts, i int) string {
+	switch {
+	case i <= len(raw.scheme) && raw.scheme != "":
+		return ComponentScheme
+	case i < raw.pathStart:
+		return ComponentAuthority
+	case i < raw.pathEnd:
+		return ComponentPath
+	case i < raw.queryEnd:
+		return ComponentQuery
+	default:
+		return ComponentFragment
+	}
+}
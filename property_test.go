@@ -0,0 +1,286 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.lsp.dev/uri"
+	"go.lsp.dev/uri/uritest"
+)
+
+// The property tests check algebraic laws of the package on Windows paths,
+// UNC paths, Unicode segments, and module cache paths from uritest.Generator.
+// They live in package uri_test because uritest imports uri.
+// TestProperties runs every law on a fixed sequence of random cases;
+// FuzzProperties lets the fuzzer drive the same generators. Laws that are
+// expected not to hold are pinned by TestPropertyCounterexamples and listed in
+// docs/properties.md.
+
+// propertyLaw is an algebraic law checked on generated cases. check returns
+// an error describing the counterexample when the law does not hold.
+type propertyLaw struct {
+	name  string
+	check func(g *uritest.Generator) error
+}
+
+var propertyLaws = []propertyLaw{
+	{
+		name: "Parse(u.String()) == u",
+		check: func(g *uritest.Generator) error {
+			u := g.URI(true)
+			if v, err := uri.Parse(u.String()); err != nil || v != u {
+				return fmt.Errorf("u = %q: Parse(u.String()) = %q, %v", u, v, err)
+			}
+			return nil
+		},
+	},
+	{
+		name: "Parse(u.StringNoEncoding()) == u for safe components",
+		check: func(g *uritest.Generator) error {
+			u := g.URI(false)
+			s := u.StringNoEncoding()
+			if v, err := uri.Parse(s); err != nil || v != u {
+				return fmt.Errorf("u = %q: Parse(%q) = %q, %v", u, s, v, err)
+			}
+			return nil
+		},
+	},
+	{
+		name: "From(u.Components()) == u",
+		check: func(g *uritest.Generator) error {
+			u := g.URI(true)
+			if v, err := uri.From(u.Components()); err != nil || v != u {
+				return fmt.Errorf("u = %q: From(u.Components()) = %q, %v", u, v, err)
+			}
+			return nil
+		},
+	},
+	{
+		name: "u.With(Change{}) == u",
+		check: func(g *uritest.Generator) error {
+			u := g.URI(true)
+			if v, err := u.With(uri.Change{}); err != nil || v != u {
+				return fmt.Errorf("u = %q: With(Change{}) = %q, %v", u, v, err)
+			}
+			return nil
+		},
+	},
+	{
+		name: "u.With(Change{Path: &p}) is From with that path",
+		check: func(g *uritest.Generator) error {
+			u := g.URI(true)
+			p := g.POSIXPath(true)
+			v, err := u.With(uri.Change{Path: &p})
+			c := u.Components()
+			c.Path = p
+			w, werr := uri.From(c)
+			if err != nil || werr != nil || v != w {
+				return fmt.Errorf("u = %q, path %q: With = %q, %v; From = %q, %v", u, p, v, err, w, werr)
+			}
+			return nil
+		},
+	},
+	{
+		name: "Dirname(JoinPath(u, x)) == u for a simple segment x",
+		check: func(g *uritest.Generator) error {
+			u, x := g.URI(true), g.Segment(true)
+			j, err := uri.JoinPath(u, x)
+			if err != nil || uri.Dirname(j) != u {
+				return fmt.Errorf("u = %q, x = %q: JoinPath = %q, %v; Dirname = %q", u, x, j, err, uri.Dirname(j))
+			}
+			return nil
+		},
+	},
+	{
+		name: "Basename(JoinPath(u, x)) == x for a simple segment x",
+		check: func(g *uritest.Generator) error {
+			u, x := g.URI(true), g.Segment(true)
+			j, err := uri.JoinPath(u, x)
+			if err != nil || uri.Basename(j) != x {
+				return fmt.Errorf("u = %q, x = %q: JoinPath = %q, %v; Basename = %q", u, x, j, err, uri.Basename(j))
+			}
+			return nil
+		},
+	},
+	{
+		name: "ResolvePath(u, x) == JoinPath(u, x) for relative segments",
+		check: func(g *uritest.Generator) error {
+			u, x := g.URI(true), g.RelativePath(true)
+			j, jerr := uri.JoinPath(u, x)
+			r, rerr := uri.ResolvePath(u, x)
+			if jerr != nil || rerr != nil || j != r {
+				return fmt.Errorf("u = %q, x = %q: JoinPath = %q, %v; ResolvePath = %q, %v", u, x, j, jerr, r, rerr)
+			}
+			return nil
+		},
+	},
+	{
+		name: "FsPathFor(FileFor(p, x), p, false) is idempotent",
+		check: func(g *uritest.Generator) error {
+			x, p := g.Path()
+			once := uri.FsPathFor(uri.FileFor(p, x), p, false)
+			if twice := uri.FsPathFor(uri.FileFor(p, once), p, false); twice != once {
+				return fmt.Errorf("x = %q, platform %d: once = %q, twice = %q", x, p, once, twice)
+			}
+			return nil
+		},
+	},
+	{
+		name: "FileFor(p, FsPathFor(FileFor(p, x), p, false)) == FileFor(p, x)",
+		check: func(g *uritest.Generator) error {
+			x, p := g.Path()
+			u := uri.FileFor(p, x)
+			if v := uri.FileFor(p, uri.FsPathFor(u, p, false)); v != u {
+				return fmt.Errorf("x = %q, platform %d: FileFor(x) = %q, through FsPathFor = %q", x, p, u, v)
+			}
+			return nil
+		},
+	},
+	{
+		name: "FsPathFor(FileFor(Windows, x)) is x with backslashes and a lowercase drive",
+		check: func(g *uritest.Generator) error {
+			x := g.WindowsPath()
+			if g.Intn(2) == 0 {
+				x = g.UNCPath()
+			}
+			want := strings.ReplaceAll(x, "/", `\`)
+			if want[1] == ':' {
+				want = strings.ToLower(want[:1]) + want[1:]
+			} else {
+				server, rest, _ := strings.Cut(want[2:], `\`)
+				want = `\\` + strings.ToLower(server) + `\` + rest
+			}
+			if got := uri.FsPathFor(uri.FileFor(uri.PlatformWindows, x), uri.PlatformWindows, false); got != want {
+				return fmt.Errorf("x = %q: FsPathFor = %q, want %q", x, got, want)
+			}
+			return nil
+		},
+	},
+}
+
+func TestProperties(t *testing.T) {
+	t.Parallel()
+
+	n := 2000
+	if testing.Short() {
+		n = 200
+	}
+	for _, law := range propertyLaws {
+		t.Run(law.name, func(t *testing.T) {
+			t.Parallel()
+			for seed := range uint64(n) {
+				if err := law.check(uritest.NewGenerator(seed)); err != nil {
+					t.Fatalf("seed %d: %v", seed, err)
+				}
+			}
+		})
+	}
+}
+
+func FuzzProperties(f *testing.F) {
+	for i := range propertyLaws {
+		f.Add(uint8(i), []byte{})
+		f.Add(uint8(i), []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	}
+	f.Fuzz(func(t *testing.T, law uint8, data []byte) {
+		l := propertyLaws[int(law)%len(propertyLaws)]
+		if err := l.check(uritest.NewGeneratorFromBytes(data)); err != nil {
+			t.Fatalf("%s: %v", l.name, err)
+		}
+	})
+}
+
+// TestPropertyCounterexamples pins laws that intentionally do not hold, so
+// that a behavior change is noticed and docs/properties.md updated.
+func TestPropertyCounterexamples(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		got, want string
+	}{
+		// StringNoEncoding keeps '#' and '?' escaped but writes a decoded
+		// '%' as is, like vscode-uri's toString(true), so an escaped escape
+		// is decoded once more on reparse.
+		"Parse(StringNoEncoding) decodes an escaped escape": {
+			got:  string(uri.MustParse(uri.MustParse("file:///a%2541").StringNoEncoding())),
+			want: "file:///aA",
+		},
+		// vscode-uri's fsPath ignores the authority when the path is "/".
+		"FileFor(FsPathFor(u)) drops a server-only UNC authority": {
+			got:  string(uri.FileFor(uri.PlatformWindows, uri.FsPathFor(uri.FileFor(uri.PlatformWindows, `\\server`), uri.PlatformWindows, false))),
+			want: "file:///",
+		},
+		// FsPathFor(FileFor(x)) is idempotent but not the identity.
+		"FsPathFor(FileFor(x)) lowercases the drive letter": {
+			got:  uri.FsPathFor(uri.FileFor(uri.PlatformWindows, `C:\X`), uri.PlatformWindows, false),
+			want: `c:\X`,
+		},
+		"FsPathFor(FileFor(x)) lowercases the UNC server": {
+			got:  uri.FsPathFor(uri.FileFor(uri.PlatformWindows, `\\SERVER\Share\x`), uri.PlatformWindows, false),
+			want: `\\server\Share\x`,
+		},
+		// Dirname removes the last segment of the normalized path, so a
+		// trailing slash, "..", or a segment containing "/" breaks
+		// Dirname(JoinPath(u, x)) == u.
+		"Dirname(JoinPath(u, x)) drops a trailing slash of u": {
+			got:  string(uri.Dirname(mustJoinPath(uri.MustParse("file:///a/"), "b"))),
+			want: "file:///a",
+		},
+		"Dirname(JoinPath(u, x)) with x = \"..\"": {
+			got:  string(uri.Dirname(mustJoinPath(uri.MustParse("file:///a/b"), ".."))),
+			want: "file:///",
+		},
+		// JoinPath appends absolute segments; ResolvePath restarts at them.
+		"JoinPath appends an absolute segment": {
+			got:  string(mustJoinPath(uri.MustParse("file:///a"), "/b")),
+			want: "file:///a/b",
+		},
+		"ResolvePath restarts at an absolute segment": {
+			got:  string(mustResolvePath(uri.MustParse("file:///a"), "/b")),
+			want: "file:///b",
+		},
+		// From and With rebuild from decoded components, so an input's
+		// escaping of unreserved characters is not kept.
+		"From(Parse(s).Components()) is canonical, not s": {
+			got:  string(mustFrom(uri.MustParse("file:///%61").Components())),
+			want: "file:///a",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if tt.got != tt.want {
+				t.Fatalf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func mustJoinPath(u uri.URI, segments ...string) uri.URI {
+	v, err := uri.JoinPath(u, segments...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func mustResolvePath(u uri.URI, segments ...string) uri.URI {
+	v, err := uri.ResolvePath(u, segments...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func mustFrom(c uri.Components) uri.URI {
+	u, err := uri.From(c)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// ParseRFC3986 parses s like ParseStrict after checking it against the
+// RFC 3986 URI grammar.
+//
+// It rejects input that vscode-uri repairs silently: raw spaces and other
+// bytes outside the component's character set, '%' not followed by two hex
+// digits, hosts that are neither a reg-name nor a bracketed IPv6 or IPvFuture
+// literal, and non-numeric ports. Failures are *Error values carrying the
+// offset of the offending byte. The returned URI is in the same canonical form
+// Parse produces.
+func ParseRFC3986(s string) (URI, error) {
+	return rfc3986Parser.Parse(s)
+}
+
+var rfc3986Parser = NewParser(ParseOptions{Strict: true, RFC3986: true})
+
+func validateRFC3986(s string, raw *rawParts) error {
+	if err := validateRFCScheme(s, raw); err != nil {
+		return err
+	}
+	if raw.authorityEnd > raw.authorityStart {
+		if err := validateRFCAuthority(s, raw.authorityStart, raw.authorityEnd); err != nil {
+			return err
+		}
+	}
+	if err := validateRFCChars(s, raw.pathStart, raw.pathEnd, isRFCPathByte, ErrInvalidCharacter, ComponentPath); err != nil {
+		return err
+	}
+	if raw.hasQuery {
+		if err := validateRFCChars(s, raw.pathEnd+1, raw.queryEnd, isRFCQueryByte, ErrInvalidCharacter, ComponentQuery); err != nil {
+			return err
+		}
+	}
+	if raw.hasFragment {
+		return validateRFCChars(s, raw.fragmentStart, len(s), isRFCQueryByte, ErrInvalidCharacter, ComponentFragment)
+	}
+	return nil
+}
+
+func validateRFCScheme(s string, raw *rawParts) error {
+	if raw.scheme == "" {
+		return uriErrorAt("parse", s, ErrMissingScheme, 0, ComponentScheme)
+	}
+	for i := 0; i < len(raw.scheme); i++ {
+		c := raw.scheme[i]
+		if isAlpha(c) || i > 0 && (isDigit(c) || c == '+' || c == '-' || c == '.') {
+			continue
+		}
+		return uriErrorAt("parse", s, ErrInvalidScheme, i, ComponentScheme)
+	}
+	return nil
+}
+
+// validateRFCAuthority checks s[start:end] against
+// [ userinfo "@" ] host [ ":" port ].
+func validateRFCAuthority(s string, start, end int) error {
+	hostStart := start
+	if at := strings.LastIndexByte(s[start:end], '@'); at >= 0 {
+		hostStart = start + at + 1
+		if err := validateRFCChars(s, start, hostStart-1, isRFCUserinfoByte, ErrInvalidCharacter, ComponentAuthority); err != nil {
+			return err
+		}
+	}
+	hostEnd, err := validateRFCHost(s, hostStart, end)
+	if err != nil {
+		return err
+	}
+	if hostEnd == end {
+		return nil
+	}
+	if s[hostEnd] != ':' {
+		return uriErrorAt("parse", s, ErrInvalidHost, hostEnd, ComponentAuthority)
+	}
+	for i := hostEnd + 1; i < end; i++ {
+		if !isDigit(s[i]) {
+			return uriErrorAt("parse", s, ErrInvalidPort, i, ComponentAuthority)
+		}
+	}
+	return nil
+}
+
+// validateRFCHost checks the host starting at s[start] and returns the offset
+// just past it.
+func validateRFCHost(s string, start, end int) (int, error) {
+	if start == end || s[start] != '[' {
+		hostEnd := start
+		for hostEnd < end && s[hostEnd] != ':' {
+			hostEnd++
+		}
+		if err := validateRFCChars(s, start, hostEnd, isRFCRegNameByte, ErrInvalidHost, ComponentAuthority); err != nil {
+			return 0, err
+		}
+		return hostEnd, nil
+	}
+	closing := strings.IndexByte(s[start:end], ']')
+	if closing < 0 {
+		return 0, uriErrorAt("parse", s, ErrInvalidHost, start, ComponentAuthority)
+	}
+	literal := s[start+1 : start+closing]
+	if !validIPLiteral(literal) {
+		return 0, uriErrorAt("parse", s, ErrInvalidHost, start+1, ComponentAuthority)
+	}
+	return start + closing + 1, nil
+}
+
+// validIPLiteral reports whether literal is an IPv6address or IPvFuture.
+func validIPLiteral(literal string) bool {
+	if literal != "" && (literal[0] == 'v' || literal[0] == 'V') {
+		version, rest, ok := strings.Cut(literal[1:], ".")
+		if !ok || version == "" || rest == "" {
+			return false
+		}
+		for i := 0; i < len(version); i++ {
+			if uriCharClass[version[i]]&charClassHex == 0 {
+				return false
+			}
+		}
+		for i := 0; i < len(rest); i++ {
+			if !isRFCUnreservedOrSubDelim(rest[i]) && rest[i] != ':' {
+				return false
+			}
+		}
+		return true
+	}
+	addr, err := netip.ParseAddr(literal)
+	return err == nil && addr.Is6() && addr.Zone() == ""
+}
+
+// validateRFCChars checks that s[start:end] consists of bytes accepted by
+// allowed and well-formed percent-encoded triplets, reporting other bytes as
+// invalid.
+func validateRFCChars(s string, start, end int, allowed func(byte) bool, invalid error, component string) error {
+	for i := start; i < end; i++ {
+		c := s[i]
+		if c == '%' {
+			if i+2 >= end || !isHexDigit(s[i+1]) || !isHexDigit(s[i+2]) {
+				return uriErrorAt("parse", s, ErrInvalidPercentEncoding, i, component)
+			}
+			i += 2
+			continue
+		}
+		if !allowed(c) {
+			return uriErrorAt("parse", s, invalid, i, component)
+		}
+	}
+	return nil
+}
+
+func isRFCUnreservedOrSubDelim(c byte) bool {
+	return uriCharClass[c]&(charClassUnreserved|charClassSubDelim) != 0
+}
+
+func isRFCRegNameByte(c byte) bool {
+	return isRFCUnreservedOrSubDelim(c)
+}
+
+func isRFCUserinfoByte(c byte) bool {
+	return isRFCUnreservedOrSubDelim(c) || c == ':'
+}
+
+func isRFCPathByte(c byte) bool {
+	return isRFCUnreservedOrSubDelim(c) || c == ':' || c == '@' || c == '/'
+}
+
+func isRFCQueryByte(c byte) bool {
+	return isRFCPathByte(c) || c == '?'
+}
+
+func isHexDigit(c byte) bool {
+	return uriCharClass[c]&charClassHex != 0
+}
+
+func isAlpha(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
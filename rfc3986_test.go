@@ -0,0 +1,134 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRFC3986(t *testing.T) {
+	tests := map[string]struct {
+		input         string
+		want          string
+		wantErr       error
+		wantOffset    int
+		wantComponent string
+	}{
+		"success: http with port and query": {
+			input: "http://user:pw@example.com:8080/a/b?x=1&y=2#frag",
+			want:  "http://user:pw@example.com:8080/a/b?x%3D1%26y%3D2#frag",
+		},
+		"success: file": {input: "file:///home/user/x.go", want: "file:///home/user/x.go"},
+		"success: percent triplet": {
+			input: "file:///a%20b.go",
+			want:  "file:///a%20b.go",
+		},
+		"success: ipv6 literal": {input: "http://[::1]:80/", want: "http://[::1]:80/"},
+		"success: ipvfuture":    {input: "http://[v7.a:b]/", want: "http://[v7.a:b]/"},
+		"success: empty port":   {input: "http://host:/", want: "http://host:/"},
+		"success: no authority": {input: "urn:isbn:0451450523", want: "urn:isbn%3A0451450523"},
+		"success: sub-delims":   {input: "foo:/a!$&'()*+,;=@:", want: "foo:/a%21%24%26%27%28%29%2A%2B%2C%3B%3D%40%3A"},
+		"error: missing scheme": {input: "/a/b", wantErr: ErrMissingScheme, wantOffset: 0, wantComponent: ComponentScheme},
+		"error: scheme digit":   {input: "1abc:x", wantErr: ErrInvalidScheme, wantOffset: 0, wantComponent: ComponentScheme},
+		"error: scheme underscore": {
+			input:         "a_b:x",
+			wantErr:       ErrInvalidScheme,
+			wantOffset:    1,
+			wantComponent: ComponentScheme,
+		},
+		"error: raw space in path": {
+			input:         "file:///a b.go",
+			wantErr:       ErrInvalidCharacter,
+			wantOffset:    9,
+			wantComponent: ComponentPath,
+		},
+		"error: bad percent triplet": {
+			input:         "file:///a%zz",
+			wantErr:       ErrInvalidPercentEncoding,
+			wantOffset:    9,
+			wantComponent: ComponentPath,
+		},
+		"error: truncated percent triplet in query": {
+			input:         "http://h/p?a=%4",
+			wantErr:       ErrInvalidPercentEncoding,
+			wantOffset:    13,
+			wantComponent: ComponentQuery,
+		},
+		"error: raw hash-like byte in fragment": {
+			input:         "http://h/p#a#b",
+			wantErr:       ErrInvalidCharacter,
+			wantOffset:    12,
+			wantComponent: ComponentFragment,
+		},
+		"error: illegal host character": {
+			input:         "http://exa<mple.com/",
+			wantErr:       ErrInvalidHost,
+			wantOffset:    10,
+			wantComponent: ComponentAuthority,
+		},
+		"error: unterminated ip literal": {
+			input:         "http://[::1/",
+			wantErr:       ErrInvalidHost,
+			wantOffset:    7,
+			wantComponent: ComponentAuthority,
+		},
+		"error: ipv4 in brackets": {
+			input:         "http://[127.0.0.1]/",
+			wantErr:       ErrInvalidHost,
+			wantOffset:    8,
+			wantComponent: ComponentAuthority,
+		},
+		"error: bytes after ip literal": {
+			input:         "http://[::1]x/",
+			wantErr:       ErrInvalidHost,
+			wantOffset:    12,
+			wantComponent: ComponentAuthority,
+		},
+		"error: non-numeric port": {
+			input:         "http://host:8o80/",
+			wantErr:       ErrInvalidPort,
+			wantOffset:    13,
+			wantComponent: ComponentAuthority,
+		},
+		"error: bad userinfo": {
+			input:         "http://us er@host/",
+			wantErr:       ErrInvalidCharacter,
+			wantOffset:    9,
+			wantComponent: ComponentAuthority,
+		},
+		"error: path without authority starts with two slashes": {
+			input:         "foo:////x",
+			wantErr:       ErrPathAuthority,
+			wantOffset:    6,
+			wantComponent: ComponentPath,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseRFC3986(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseRFC3986(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				var uerr *Error
+				if !errors.As(err, &uerr) {
+					t.Fatalf("ParseRFC3986(%q) error = %T, want *Error", tt.input, err)
+				}
+				if uerr.Offset != tt.wantOffset || uerr.Component != tt.wantComponent {
+					t.Fatalf("ParseRFC3986(%q) position = %d in %q, want %d in %q", tt.input, uerr.Offset, uerr.Component, tt.wantOffset, tt.wantComponent)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRFC3986(%q) error = %v", tt.input, err)
+			}
+			if got.String() != tt.want {
+				t.Fatalf("ParseRFC3986(%q) = %q, want %q", tt.input, got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	schemeUntitled       = "untitled"
+	schemeNotebookCell   = "vscode-notebook-cell"
+	schemeVscodeUserData = "vscode-userdata"
+	schemeOutput         = "output"
+	schemeGit            = "git"
+	schemeVscodeVFS      = "vscode-vfs"
+)
+
+// SchemeInfo describes how the package treats URIs of a scheme.
+//
+// Hierarchical changes the canonical string of the scheme's URIs, so register
+// schemes before parsing URIs of them; URIs parsed earlier keep their old form
+// and may no longer compare equal to new ones.
+type SchemeInfo struct {
+	// FileBacked reports that the path names a filesystem location, so
+	// FsPath treats an authority as a UNC host as it does for file URIs.
+	FileBacked bool
+	// Hierarchical reports that paths are slash-rooted hierarchies. Parse,
+	// From, and With root their paths at "/" as vscode-uri does for file and
+	// http URIs, and JoinPath and Dirname keep results rooted.
+	Hierarchical bool
+	// CaseSensitive reports that paths differing only in case name different
+	// resources. Equal compares paths of schemes registered without it
+	// case-insensitively.
+	CaseSensitive bool
+	// Authority says whether From and With require or forbid an authority.
+	Authority AuthorityPolicy
+}
+
+// AuthorityPolicy says whether URIs of a scheme have an authority.
+type AuthorityPolicy uint8
+
+const (
+	// AuthorityOptional accepts URIs with or without an authority.
+	AuthorityOptional AuthorityPolicy = iota
+	// AuthorityRequired rejects URIs without an authority.
+	AuthorityRequired
+	// AuthorityForbidden rejects URIs with an authority.
+	AuthorityForbidden
+)
+
+// The virtual schemes VS Code sends are registered as opaque, so their path
+// utilities behave as in vscode-uri: JoinPath(git:foo, bar) is git:foo/bar.
+// Built-in schemes are case-sensitive and leave the authority optional, as
+// vscode-uri does; whether file paths fold case depends on the file system.
+var schemes = struct {
+	sync.RWMutex
+	m map[string]SchemeInfo
+}{
+	m: map[string]SchemeInfo{
+		schemeFile:           {FileBacked: true, Hierarchical: true, CaseSensitive: true},
+		schemeHTTP:           {Hierarchical: true, CaseSensitive: true},
+		schemeHTTPS:          {Hierarchical: true, CaseSensitive: true},
+		schemeUntitled:       {CaseSensitive: true},
+		schemeNotebookCell:   {CaseSensitive: true},
+		schemeVscodeUserData: {CaseSensitive: true},
+		schemeOutput:         {CaseSensitive: true},
+		schemeGit:            {CaseSensitive: true},
+		schemeVscodeVFS:      {CaseSensitive: true},
+	},
+}
+
+// RegisterScheme records info for the named scheme, replacing any earlier
+// registration. It panics if name is not a valid scheme or is one of the file,
+// http, or https schemes whose behavior vscode-uri fixes.
+func RegisterScheme(name string, info SchemeInfo) {
+	if !validScheme(name) {
+		panic(uriError("register scheme", name, ErrInvalidScheme))
+	}
+	switch name {
+	case schemeFile, schemeHTTP, schemeHTTPS:
+		panic("uri: cannot re-register built-in scheme " + name)
+	}
+	schemes.Lock()
+	schemes.m[name] = info
+	schemes.Unlock()
+}
+
+// LookupScheme returns the information registered for the named scheme.
+func LookupScheme(name string) (SchemeInfo, bool) {
+	schemes.RLock()
+	info, ok := schemes.m[name]
+	schemes.RUnlock()
+	return info, ok
+}
+
+// Equal reports whether a and b name the same resource. They must agree on
+// every component, except that paths of a scheme registered without
+// CaseSensitive may differ in case.
+func Equal(a, b URI) bool {
+	if a == b {
+		return true
+	}
+	ra, rb := splitRaw(string(a)), splitRaw(string(b))
+	if ra.scheme != rb.scheme || ra.authority != rb.authority ||
+		ra.query != rb.query || ra.hasQuery != rb.hasQuery ||
+		ra.fragment != rb.fragment || ra.hasFragment != rb.hasFragment {
+		return false
+	}
+	info, ok := LookupScheme(ra.scheme)
+	if !ok || info.CaseSensitive {
+		return false
+	}
+	return strings.EqualFold(ra.path, rb.path)
+}
+
+// checkAuthority applies the Authority policy registered for the scheme of c.
+func checkAuthority(c *Components, op, input string) error {
+	info, ok := LookupScheme(c.Scheme)
+	if !ok {
+		return nil
+	}
+	switch {
+	case info.Authority == AuthorityRequired && c.Authority == "":
+		return uriError(op, input, ErrMissingAuthority)
+	case info.Authority == AuthorityForbidden && c.Authority != "":
+		return uriError(op, input, ErrAuthorityNotAllowed)
+	}
+	return nil
+}
+
+func isHierarchicalScheme(scheme string) bool {
+	switch scheme {
+	case schemeFile, schemeHTTP, schemeHTTPS:
+		return true
+	}
+	info, ok := LookupScheme(scheme)
+	return ok && info.Hierarchical
+}
+
+func isFileBackedScheme(scheme string) bool {
+	if scheme == schemeFile {
+		return true
+	}
+	info, ok := LookupScheme(scheme)
+	return ok && info.FileBacked
+}
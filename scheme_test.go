@@ -0,0 +1,291 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// registerTestScheme registers a scheme for the duration of t. Callers use
+// names no other test registers and must not run in parallel with tests that
+// look the scheme up.
+func registerTestScheme(t *testing.T, name string, info SchemeInfo) {
+	t.Helper()
+	RegisterScheme(name, info)
+	t.Cleanup(func() {
+		schemes.Lock()
+		delete(schemes.m, name)
+		schemes.Unlock()
+	})
+}
+
+func TestLookupSchemeBuiltins(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		scheme string
+		want   SchemeInfo
+	}{
+		"file":                 {scheme: "file", want: SchemeInfo{FileBacked: true, Hierarchical: true, CaseSensitive: true}},
+		"http":                 {scheme: "http", want: SchemeInfo{Hierarchical: true, CaseSensitive: true}},
+		"https":                {scheme: "https", want: SchemeInfo{Hierarchical: true, CaseSensitive: true}},
+		"untitled":             {scheme: "untitled", want: SchemeInfo{CaseSensitive: true}},
+		"vscode-notebook-cell": {scheme: "vscode-notebook-cell", want: SchemeInfo{CaseSensitive: true}},
+		"vscode-userdata":      {scheme: "vscode-userdata", want: SchemeInfo{CaseSensitive: true}},
+		"output":               {scheme: "output", want: SchemeInfo{CaseSensitive: true}},
+		"git":                  {scheme: "git", want: SchemeInfo{CaseSensitive: true}},
+		"vscode-vfs":           {scheme: "vscode-vfs", want: SchemeInfo{CaseSensitive: true}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := LookupScheme(tt.scheme)
+			if !ok {
+				t.Fatalf("LookupScheme(%q) not registered", tt.scheme)
+			}
+			if got != tt.want {
+				t.Fatalf("LookupScheme(%q) = %+v, want %+v", tt.scheme, got, tt.want)
+			}
+		})
+	}
+	if _, ok := LookupScheme("never-registered"); ok {
+		t.Fatal("LookupScheme(never-registered) reported true")
+	}
+}
+
+func TestRegisterSchemePanics(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		scheme    string
+		wantError error
+	}{
+		"error: invalid scheme":    {scheme: "bad scheme", wantError: ErrInvalidScheme},
+		"error: built-in file":     {scheme: "file"},
+		"error: built-in http":     {scheme: "http"},
+		"error: built-in https":    {scheme: "https"},
+		"error: empty scheme name": {scheme: "", wantError: ErrInvalidScheme},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Fatal("RegisterScheme did not panic")
+				}
+				if tt.wantError != nil {
+					if err, ok := r.(error); !ok || !errors.Is(err, tt.wantError) {
+						t.Fatalf("RegisterScheme panic = %v, want %v", r, tt.wantError)
+					}
+				}
+			}()
+			RegisterScheme(tt.scheme, SchemeInfo{})
+		})
+	}
+}
+
+func TestSchemeRegistryPathUtilities(t *testing.T) {
+	registerTestScheme(t, "test-registry-tree", SchemeInfo{Hierarchical: true})
+	registerTestScheme(t, "test-registry-share", SchemeInfo{FileBacked: true, Hierarchical: true})
+
+	tests := map[string]struct {
+		uri      string
+		segments []string
+		wantJoin string
+		wantDir  string
+		wantFs   string
+	}{
+		"success: hierarchical authority-only join is rooted": {
+			uri:      "test-registry-tree://github",
+			segments: []string{"owner", "repo"},
+			wantJoin: "test-registry-tree://github/owner/repo",
+			wantDir:  "test-registry-tree://github/",
+			wantFs:   "/",
+		},
+		"success: opaque git join stays relative": {
+			uri:      "git:foo",
+			segments: []string{"bar"},
+			wantJoin: "git:foo/bar",
+			wantDir:  "git:",
+			wantFs:   "foo",
+		},
+		"success: registered hierarchical path is rooted": {
+			uri:      "test-registry-tree:x",
+			segments: []string{"y"},
+			wantJoin: "test-registry-tree:/x/y",
+			wantDir:  "test-registry-tree:/",
+			wantFs:   "/x",
+		},
+		"success: opaque untitled keeps vscode relative behavior": {
+			uri:      "untitled:untitled-1",
+			segments: []string{"..", "untitled-2"},
+			wantJoin: "untitled:untitled-2",
+			wantDir:  "untitled:",
+			wantFs:   "untitled-1",
+		},
+		"success: file-backed authority is a unc host": {
+			uri:      "test-registry-share://server/share/x.go",
+			segments: []string{"y.go"},
+			wantJoin: "test-registry-share://server/share/x.go/y.go",
+			wantDir:  "test-registry-share://server/share",
+			wantFs:   "//server/share/x.go",
+		},
+		"success: non file-backed authority is dropped": {
+			uri:      "vscode-vfs://github/owner/repo",
+			segments: []string{"go.mod"},
+			wantJoin: "vscode-vfs://github/owner/repo/go.mod",
+			wantDir:  "vscode-vfs://github/owner",
+			wantFs:   "/owner/repo",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u := MustParse(tt.uri)
+			joined, err := JoinPath(u, tt.segments...)
+			if err != nil {
+				t.Fatalf("JoinPath() error = %v", err)
+			}
+			if joined.String() != tt.wantJoin {
+				t.Fatalf("JoinPath() = %q, want %q", joined.String(), tt.wantJoin)
+			}
+			if got := Dirname(u).String(); got != tt.wantDir {
+				t.Fatalf("Dirname() = %q, want %q", got, tt.wantDir)
+			}
+			if got := FsPathFor(u, PlatformPOSIX, false); got != tt.wantFs {
+				t.Fatalf("FsPathFor() = %q, want %q", got, tt.wantFs)
+			}
+		})
+	}
+}
+
+func TestSchemeRegistryHierarchicalParse(t *testing.T) {
+	registerTestScheme(t, "test-registry-parse", SchemeInfo{Hierarchical: true})
+
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"success: registered hierarchical dot-segment path is rooted": {
+			input: "test-registry-parse:../a/./b",
+			want:  "test-registry-parse:/../a/./b",
+		},
+		"success: registered hierarchical empty path is root": {
+			input: "test-registry-parse:",
+			want:  "test-registry-parse:/",
+		},
+		"success: registered hierarchical rooted path is unchanged": {
+			input: "test-registry-parse:/a/../b",
+			want:  "test-registry-parse:/a/../b",
+		},
+		"success: opaque dot-segment path stays relative": {
+			input: "untitled:../a/./b",
+			want:  "untitled:../a/./b",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := MustParse(tt.input).String(); got != tt.want {
+				t.Fatalf("Parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			for got, err := range ParseAll(slices.Values([]string{tt.input})) {
+				if err != nil {
+					t.Fatalf("ParseAll(%q) error = %v", tt.input, err)
+				}
+				if got.String() != tt.want {
+					t.Fatalf("ParseAll(%q) = %q, want %q", tt.input, got.String(), tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	registerTestScheme(t, "test-registry-fold", SchemeInfo{Hierarchical: true})
+
+	tests := map[string]struct {
+		a, b string
+		want bool
+	}{
+		"success: identical":                           {a: "file:///a/B.go", b: "file:///a/B.go", want: true},
+		"success: case-insensitive scheme folds path":  {a: "test-registry-fold:/a/B.go", b: "test-registry-fold:/A/b.go", want: true},
+		"error: case-sensitive file path":              {a: "file:///a/B.go", b: "file:///a/b.go"},
+		"error: unregistered scheme is case-sensitive": {a: "never-registered:/a/B", b: "never-registered:/a/b"},
+		"error: case-insensitive scheme keeps query":   {a: "test-registry-fold:/a?Q", b: "test-registry-fold:/a?q"},
+		"error: case-insensitive scheme keeps authority": {
+			a: "test-registry-fold://host/a",
+			b: "test-registry-fold://other/A",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			a, b := MustParse(tt.a), MustParse(tt.b)
+			if got := Equal(a, b); got != tt.want {
+				t.Fatalf("Equal(%q, %q) = %v, want %v", a, b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemeAuthorityPolicy(t *testing.T) {
+	registerTestScheme(t, "test-registry-host", SchemeInfo{Hierarchical: true, Authority: AuthorityRequired})
+	registerTestScheme(t, "test-registry-local", SchemeInfo{Hierarchical: true, Authority: AuthorityForbidden})
+
+	tests := map[string]struct {
+		c         Components
+		want      string
+		wantError error
+	}{
+		"success: required authority present": {
+			c:    Components{Scheme: "test-registry-host", Authority: "host", Path: "/a"},
+			want: "test-registry-host://host/a",
+		},
+		"success: forbidden authority absent": {
+			c:    Components{Scheme: "test-registry-local", Path: "/a"},
+			want: "test-registry-local:/a",
+		},
+		"success: built-in authority optional": {
+			c:    Components{Scheme: "file", Path: "/a"},
+			want: "file:///a",
+		},
+		"error: required authority missing": {
+			c:         Components{Scheme: "test-registry-host", Path: "/a"},
+			wantError: ErrMissingAuthority,
+		},
+		"error: forbidden authority present": {
+			c:         Components{Scheme: "test-registry-local", Authority: "host", Path: "/a"},
+			wantError: ErrAuthorityNotAllowed,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := From(tt.c)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("From() error = %v, want %v", err, tt.wantError)
+			}
+			if got.String() != tt.want {
+				t.Fatalf("From() = %q, want %q", got.String(), tt.want)
+			}
+			base := MustParse("untitled:x")
+			with, err := base.With(Change{
+				Scheme:    &tt.c.Scheme,
+				Authority: &tt.c.Authority,
+				Path:      &tt.c.Path,
+			})
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("With() error = %v, want %v", err, tt.wantError)
+			}
+			if with.String() != tt.want {
+				t.Fatalf("With() = %q, want %q", with.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+// swarHigh has the high bit of every byte of a word set.
+const swarHigh = 0x8080808080808080
+
+// swarMinLen is the shortest component worth a call to passPrefix; shorter
+// ones such as typical queries scan faster in an inlined byte loop.
+const swarMinLen = 16
+
+// passPrefix returns the length of the longest prefix of s whose bytes pass
+// canPassFast(c, isPath, false).
+func passPrefix(s string, isPath bool) int {
+	if isPath {
+		return pathPassPrefix(s)
+	}
+	return unreservedPrefix(s)
+}
+
+// pathPassPrefix classifies eight bytes per step with the generated
+// swarPathPass and finishes the tail byte by byte.
+func pathPassPrefix(s string) int {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		w := loadWord(s, i)
+		if w&swarHigh != 0 || swarPathPass(w)&swarHigh != swarHigh {
+			break
+		}
+	}
+	for ; i < len(s); i++ {
+		if uriCharClass[s[i]]&(charClassUnreserved|charClassPathExtra) == 0 {
+			break
+		}
+	}
+	return i
+}
+
+// unreservedPrefix is pathPassPrefix for bytes that are unreserved.
+func unreservedPrefix(s string) int {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		w := loadWord(s, i)
+		if w&swarHigh != 0 || swarUnreserved(w)&swarHigh != swarHigh {
+			break
+		}
+	}
+	for ; i < len(s); i++ {
+		if uriCharClass[s[i]]&charClassUnreserved == 0 {
+			break
+		}
+	}
+	return i
+}
+
+// loadWord returns s[i:i+8] as a little-endian word, so byte i is the lowest.
+func loadWord(s string, i int) uint64 {
+	s = s[i : i+8]
+	return uint64(s[0]) | uint64(s[1])<<8 | uint64(s[2])<<16 | uint64(s[3])<<24 |
+		uint64(s[4])<<32 | uint64(s[5])<<40 | uint64(s[6])<<48 | uint64(s[7])<<56
+}
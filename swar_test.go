@@ -0,0 +1,36 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPassPrefixEveryByte(t *testing.T) {
+	t.Parallel()
+
+	for _, isPath := range []bool{false, true} {
+		for c := range 256 {
+			for pos := range 8 {
+				b := []byte(strings.Repeat("a", 16))
+				b[pos] = byte(c)
+				s := string(b)
+				if got, want := passPrefix(s, isPath), scalarPassPrefix(s, isPath); got != want {
+					t.Fatalf("passPrefix(%q, %t) = %d, want %d", s, isPath, got, want)
+				}
+			}
+		}
+	}
+}
+
+func scalarPassPrefix(s string, isPath bool) int {
+	for i := 0; i < len(s); i++ {
+		if !canPassFast(s[i], isPath, false) {
+			return i
+		}
+	}
+	return len(s)
+}
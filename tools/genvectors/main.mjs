@@ -2,8 +2,10 @@
 import { readFileSync, writeFileSync } from 'node:fs';
 import { createRequire } from 'node:module';
 
+import { parseVector } from './reference.mjs';
+
 const require = createRequire(import.meta.url);
-const out = new URL('../../testdata/vectors.json', import.meta.url);
+const out = new URL('../../uritest/vectors.json', import.meta.url);
 const packageFile = new URL('./package.json', import.meta.url);
 const useStaticFixture = process.argv.includes('--use-static-fixture');
 
@@ -41,6 +43,7 @@ function referencePayload() {
 
   const payload = {
     ...base,
+    version: 2,
     generator: 'vscode-uri-canonical-reparse',
     vscodeURIVersion: pinnedVscodeURIVersion(),
     generatedAt: new Date(0).toISOString(),
@@ -52,29 +55,18 @@ function referencePayload() {
       'parse.fsPathPOSIX.fromCanonicalReparse',
       'parse.fsPathWindows.fromCanonicalReparse',
       'paths',
+      'with',
+      'from',
+      'file',
+      'notebookCells',
     ],
-    curated: ['errors'],
+    curated: ['errors', 'parseOptions'],
     note:
       'Go URI values compare by canonical string identity. Parse vectors derive component and fsPath fields by reparsing vscode-uri toString() output, not by preserving original parse-history casing.',
   };
   payload.parse = uniqueParseInputs.map((input) => {
-    const canonical = URI.parse(URI.parse(input).toString());
     const existing = base.parse.find((v) => v.input === input);
-    return {
-      name: existing?.name ?? input,
-      input,
-      components: {
-        scheme: canonical.scheme,
-        authority: canonical.authority,
-        path: canonical.path,
-        query: canonical.query,
-        fragment: canonical.fragment,
-      },
-      string: canonical.toString(),
-      stringNoEncoding: canonical.toString(true),
-      fsPathPOSIX: fsPathFor(canonical, false),
-      fsPathWindows: fsPathFor(canonical, true),
-    };
+    return parseVector(URI, input, existing?.name);
   });
   payload.paths = base.paths.map((v) => {
     const u = URI.parse(v.uri);
@@ -100,19 +92,81 @@ function referencePayload() {
     }
     return { ...v, want: got };
   });
+  payload.with = (base.with ?? []).map((v) => {
+    const { want, throws, ...rest } = v;
+    return withResult(rest, () => URI.parse(v.uri).with(v.change));
+  });
+  payload.from = (base.from ?? []).map((v) => {
+    const { want, throws, ...rest } = v;
+    return withResult(rest, () => URI.from(v.components));
+  });
+  payload.file = (base.file ?? []).map((v) => {
+    // vscode-uri picks its separator handling from process.platform when it
+    // is loaded, so apply its Windows backslash rewrite explicitly.
+    let path;
+    switch (v.platform) {
+      case 'posix':
+        path = v.path;
+        break;
+      case 'windows':
+        path = v.path.replaceAll('\\', '/');
+        break;
+      default:
+        throw new Error(`unknown platform ${v.platform}`);
+    }
+    return { ...v, want: URI.file(path).toString() };
+  });
+  payload.notebookCells = (base.notebookCells ?? []).map((v) => {
+    const notebook = URI.parse(v.notebook);
+    const cell = cellURIGenerate(notebook, v.handle);
+    const parsed = cellURIParse(URI.parse(cell.toString()));
+    if (!parsed || parsed.handle !== v.handle || parsed.notebook.toString() !== notebook.toString()) {
+      throw new Error(`CellUri round trip failed for ${v.name}`);
+    }
+    return { ...v, cell: cell.toString() };
+  });
   return payload;
 }
 
-function fsPathFor(u, windows) {
-  let value;
-  if (u.scheme === 'file' && u.authority && u.path.length > 1) {
-    value = `//${u.authority}${u.path}`;
-  } else if (/^\/[A-Za-z]:/.test(u.path)) {
-    value = `${u.path[1].toLowerCase()}${u.path.slice(2)}`;
-  } else {
-    value = u.path;
+// withResult returns vector with the string form of the URI that fn returns
+// as want, or with throws set when fn throws.
+function withResult(vector, fn) {
+  try {
+    return { ...vector, want: fn().toString() };
+  } catch {
+    return { ...vector, throws: true };
+  }
+}
+
+// Port of CellUri.generate and CellUri.parse from microsoft/vscode
+// src/vs/workbench/contrib/notebook/common/notebookCommon.ts. vscode-uri does
+// not ship CellUri, so the fragment layout is reproduced here on top of the
+// pinned URI implementation.
+const cellLengths = ['W', 'X', 'Y', 'Z', 'a', 'b', 'c', 'd', 'e', 'f'];
+const cellPadRegexp = new RegExp(`^[${cellLengths.join('')}]+`);
+const cellRadix = 7;
+
+function cellURIGenerate(notebook, handle) {
+  const s = handle.toString(cellRadix);
+  const p = s.length < cellLengths.length ? cellLengths[s.length - 1] : 'z';
+  const scheme = Buffer.from(notebook.scheme).toString('base64').replaceAll('+', '-').replaceAll('/', '_');
+  return notebook.with({ scheme: 'vscode-notebook-cell', fragment: `${p}${s}s${scheme}` });
+}
+
+function cellURIParse(cell) {
+  if (cell.scheme !== 'vscode-notebook-cell') {
+    return undefined;
+  }
+  const idx = cell.fragment.indexOf('s');
+  if (idx < 0) {
+    return undefined;
+  }
+  const handle = parseInt(cell.fragment.substring(0, idx).replace(cellPadRegexp, ''), cellRadix);
+  const scheme = Buffer.from(cell.fragment.substring(idx + 1), 'base64').toString();
+  if (isNaN(handle)) {
+    return undefined;
   }
-  return windows ? value.replaceAll('/', '\\') : value;
+  return { handle, notebook: cell.with({ scheme, fragment: null }) };
 }
 
 let payload;
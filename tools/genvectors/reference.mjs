@@ -0,0 +1,38 @@
+// Reference computations shared by the vector generator (main.mjs) and the
+// differential fuzzing server (server.mjs), so that both describe the Go
+// contract the same way.
+
+// parseVector returns the parse vector for input: vscode-uri's toString()
+// output, with components and filesystem paths taken from reparsing it.
+export function parseVector(URI, input, name = input) {
+  const canonical = URI.parse(URI.parse(input).toString());
+  return {
+    name,
+    input,
+    components: {
+      scheme: canonical.scheme,
+      authority: canonical.authority,
+      path: canonical.path,
+      query: canonical.query,
+      fragment: canonical.fragment,
+    },
+    string: canonical.toString(),
+    stringNoEncoding: canonical.toString(true),
+    fsPathPOSIX: fsPathFor(canonical, false),
+    fsPathWindows: fsPathFor(canonical, true),
+  };
+}
+
+// fsPathFor mirrors vscode-uri's uriToFsPath for a chosen platform; the
+// library only exposes the fsPath of the platform Node runs on.
+export function fsPathFor(u, windows) {
+  let value;
+  if (u.scheme === 'file' && u.authority && u.path.length > 1) {
+    value = `//${u.authority}${u.path}`;
+  } else if (/^\/[A-Za-z]:/.test(u.path)) {
+    value = `${u.path[1].toLowerCase()}${u.path.slice(2)}`;
+  } else {
+    value = u.path;
+  }
+  return windows ? value.replaceAll('/', '\\') : value;
+}
@@ -0,0 +1,27 @@
+#!/usr/bin/env node
+// Line-delimited JSON server for differential testing against the pinned
+// vscode-uri. Each request line is {"input": "..."}; each response line is the
+// parse vector for that input, as main.mjs would record it, or
+// {"input": "...", "error": "..."} when vscode-uri throws.
+import { createInterface } from 'node:readline';
+import { createRequire } from 'node:module';
+
+import { parseVector } from './reference.mjs';
+
+const require = createRequire(import.meta.url);
+const { URI } = require('vscode-uri');
+
+const lines = createInterface({ input: process.stdin, crlfDelay: Infinity });
+for await (const line of lines) {
+  if (line === '') {
+    continue;
+  }
+  const { input } = JSON.parse(line);
+  let response;
+  try {
+    response = parseVector(URI, input);
+  } catch (err) {
+    response = { input, error: String(err?.message ?? err) };
+  }
+  process.stdout.write(`${JSON.stringify(response)}\n`);
+}
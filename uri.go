@@ -4,7 +4,12 @@
 
 package uri
 
-import "strings"
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
 
 const (
 	schemeFile  = "file"
@@ -26,13 +31,14 @@ const (
 // hosts is intentionally not retained.
 type URI string
 
-// Components contains decoded URI components.
+// Components contains decoded URI components. Its JSON form uses the field
+// names of vscode-uri's UriComponents.
 type Components struct {
-	Scheme    string
-	Authority string
-	Path      string
-	Query     string
-	Fragment  string
+	Scheme    string `json:"scheme"`
+	Authority string `json:"authority"`
+	Path      string `json:"path"`
+	Query     string `json:"query"`
+	Fragment  string `json:"fragment"`
 }
 
 // Parse parses s with vscode-uri non-strict semantics.
@@ -54,7 +60,8 @@ func MustParse(s string) URI {
 	return u
 }
 
-// From constructs a URI from decoded components.
+// From constructs a URI from decoded components. It enforces the Authority
+// policy registered for the scheme.
 //
 //nolint:gocritic // Components is the public value-type API shape.
 func From(c Components) (URI, error) {
@@ -66,6 +73,9 @@ func From(c Components) (URI, error) {
 	if err := validateComponents(&components, true, "from", ""); err != nil {
 		return "", err
 	}
+	if err := checkAuthority(&components, "from", ""); err != nil {
+		return "", err
+	}
 	return u, nil
 }
 
@@ -76,14 +86,107 @@ func (u URI) String() string {
 
 // StringNoEncoding returns a URI string with vscode-uri toString(true) semantics.
 func (u URI) StringNoEncoding() string {
-	components := u.Components()
-	return formatComponents(&components, true)
+	return bytesString(u.AppendNoEncoding(make([]byte, 0, len(u))))
+}
+
+// AppendNoEncoding appends the StringNoEncoding form of u to b and returns the
+// extended buffer. It decodes straight into b and allocates only when b must
+// grow or a single percent-encoded run is unusually long.
+func (u URI) AppendNoEncoding(b []byte) []byte {
+	raw := splitRaw(string(u))
+	if raw.scheme != "" {
+		b = append(b, raw.scheme...)
+		b = append(b, ':')
+	}
+	if raw.authority != "" || raw.scheme == schemeFile {
+		b = append(b, "//"...)
+	}
+	if raw.authority != "" {
+		b = appendAuthorityNoEncoding(b, raw.authority)
+	}
+	start := len(b)
+	b = appendPercentDecode(b, raw.path)
+	lowerPathDrive(b[start:])
+	b = escapeMinimal(b, start)
+	if raw.query != "" {
+		b = append(b, '?')
+		start = len(b)
+		b = appendPercentDecode(b, raw.query)
+		b = escapeMinimal(b, start)
+	}
+	if raw.fragment != "" {
+		b = append(b, '#')
+		b = appendPercentDecode(b, raw.fragment)
+	}
+	return b
+}
+
+// appendAuthorityNoEncoding appends the decoded authority the way
+// appendAuthority formats it without encoding: everything after the first '@'
+// is lowercased.
+func appendAuthorityNoEncoding(b []byte, authority string) []byte {
+	start := len(b)
+	b = appendPercentDecode(b, authority)
+	host := start
+	if at := bytes.IndexByte(b[start:], '@'); at >= 0 {
+		host = start + at + 1
+	}
+	if hasUpperOrNonASCII(b[host:]) {
+		lower := strings.ToLower(string(b[host:]))
+		b = append(b[:host], lower...)
+	}
+	return escapeMinimal(b, start)
+}
+
+// escapeMinimal percent-encodes the '#' and '?' bytes of b[start:] in place,
+// as appendComponentMinimal would have written them.
+func escapeMinimal(b []byte, start int) []byte {
+	n := 0
+	for _, c := range b[start:] {
+		if c == '#' || c == '?' {
+			n++
+		}
+	}
+	if n == 0 {
+		return b
+	}
+	end := len(b)
+	b = slices.Grow(b, 2*n)[:end+2*n]
+	for r, w := end-1, len(b)-1; r >= start; r-- {
+		c := b[r]
+		if c != '#' && c != '?' {
+			b[w] = c
+			w--
+			continue
+		}
+		i := int(c) * 3
+		copy(b[w-2:w+1], percentTriplets[i:i+3])
+		w -= 3
+	}
+	return b
+}
+
+func lowerPathDrive(path []byte) {
+	switch {
+	case len(path) >= 3 && path[0] == '/' && path[2] == ':' && isUpperASCII(path[1]):
+		path[1] += 'a' - 'A'
+	case len(path) >= 2 && path[1] == ':' && isUpperASCII(path[0]):
+		path[0] += 'a' - 'A'
+	}
+}
+
+func hasUpperOrNonASCII(b []byte) bool {
+	for _, c := range b {
+		if isUpperASCII(c) || c >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
 }
 
 // Scheme returns the URI scheme.
 func (u URI) Scheme() string {
-	raw := splitRaw(string(u))
-	return raw.scheme
+	return u.Parsed().Scheme()
 }
 
 // Authority returns the decoded canonical URI authority.
@@ -92,8 +195,7 @@ func (u URI) Scheme() string {
 // lowercased, matching URI.parse(input).toString() reparsed by vscode-uri rather
 // than vscode-uri's original parse object.
 func (u URI) Authority() string {
-	raw := splitRaw(string(u))
-	return percentDecode(raw.authority)
+	return u.Parsed().Authority()
 }
 
 // Path returns the decoded canonical URI path.
@@ -101,52 +203,27 @@ func (u URI) Authority() string {
 // Windows drive-letter casing follows the canonical URI string. For example,
 // parsing file:///C:/x and reparsing the canonical string both expose /c:/x.
 func (u URI) Path() string {
-	raw := splitRaw(string(u))
-	return percentDecode(raw.path)
+	return u.Parsed().Path()
 }
 
 // Query returns the decoded URI query.
 func (u URI) Query() string {
-	raw := splitRaw(string(u))
-	if !raw.hasQuery {
-		return ""
-	}
-	return percentDecode(raw.query)
+	return u.Parsed().Query()
 }
 
 // Fragment returns the decoded URI fragment.
 func (u URI) Fragment() string {
-	raw := splitRaw(string(u))
-	if !raw.hasFragment {
-		return ""
-	}
-	return percentDecode(raw.fragment)
+	return u.Parsed().Fragment()
 }
 
 // Components returns all decoded URI components.
 func (u URI) Components() Components {
-	raw := splitRaw(string(u))
-	query := ""
-	if raw.hasQuery {
-		query = percentDecode(raw.query)
-	}
-	fragment := ""
-	if raw.hasFragment {
-		fragment = percentDecode(raw.fragment)
-	}
-	return Components{
-		Scheme:    raw.scheme,
-		Authority: percentDecode(raw.authority),
-		Path:      percentDecode(raw.path),
-		Query:     query,
-		Fragment:  fragment,
-	}
+	return u.Parsed().Components()
 }
 
 // IsFile reports whether u has the exact file scheme.
 func (u URI) IsFile() bool {
-	raw := splitRaw(string(u))
-	return raw.scheme == schemeFile
+	return u.Parsed().IsFile()
 }
 
 // IsZero reports whether u is the zero URI value.
@@ -160,8 +237,12 @@ func parse(s string, strict bool) (URI, error) {
 	}
 
 	raw := splitRaw(s)
-	if u, ok, err := parseCanonicalFast(s, &raw, strict); ok || err != nil {
-		return u, err
+	return parseRaw(s, &raw, strict)
+}
+
+func parseRaw(s string, raw *rawParts, strict bool) (URI, error) {
+	if u, ok, err := parseCanonicalFast(s, raw, strict); ok || err != nil {
+		return u, locateError(err, raw)
 	}
 	c := Components{
 		Scheme:    raw.scheme,
@@ -170,7 +251,8 @@ func parse(s string, strict bool) (URI, error) {
 		Query:     decodeComponent(raw.query),
 		Fragment:  decodeComponent(raw.fragment),
 	}
-	return newURI(&c, strict, "parse", s)
+	u, err := newURI(&c, strict, "parse", s)
+	return u, locateError(err, raw)
 }
 
 func newURI(c *Components, strict bool, op, input string) (URI, error) {
@@ -183,15 +265,34 @@ func newURI(c *Components, strict bool, op, input string) (URI, error) {
 	return URI(formatComponents(&components, false)), nil
 }
 
+// parseCanonicalFast handles input whose decoded components format back to
+// the raw text apart from escaping, which covers already-canonical URIs and
+// dirty ones such as "https://host/p?name=ferret" without a '%' to decode. It
+// validates and counts escapes in one scan over the raw parts, then returns s
+// itself or escapes into a buffer of the exact final size.
 func parseCanonicalFast(s string, raw *rawParts, strict bool) (URI, bool, error) {
-	if err := validateRawScheme(s, raw, strict); err != nil {
+	escapes, ok, err := canonicalEscapes(s, raw, strict)
+	if !ok || err != nil {
 		return "", false, err
 	}
-	if raw.scheme == "" {
-		return "", false, nil
+	if escapes == 0 {
+		return URI(s), true, nil
 	}
-	if !rawPartsAreCanonical(s, raw) {
-		return "", false, nil
+	return URI(escapeRawParts(s, raw, escapes)), true, nil
+}
+
+// canonicalEscapes reports whether parseCanonicalFast applies to s and, if
+// so, how many bytes it escapes.
+func canonicalEscapes(s string, raw *rawParts, strict bool) (int, bool, error) {
+	if err := validateRawScheme(s, raw, strict); err != nil {
+		return 0, false, err
+	}
+	if raw.scheme == "" || !rawLayoutIsCanonical(s, raw) {
+		return 0, false, nil
+	}
+	escapes, ok := countRawEscapes(raw)
+	if !ok {
+		return 0, false, nil
 	}
 	c := Components{
 		Scheme:    raw.scheme,
@@ -201,40 +302,99 @@ func parseCanonicalFast(s string, raw *rawParts, strict bool) (URI, bool, error)
 		Fragment:  raw.fragment,
 	}
 	if err := validateComponents(&c, strict, "parse", s); err != nil {
-		return "", false, err
-	}
-	return URI(s), true, nil
-}
-
-func validateRawScheme(s string, raw *rawParts, strict bool) error {
-	if raw.scheme == "" {
-		if strict {
-			return uriError("parse", s, ErrMissingScheme)
-		}
-		return nil
-	}
-	if !validScheme(raw.scheme) {
-		return uriError("parse", s, ErrInvalidScheme)
+		return 0, false, err
 	}
-	return nil
+	return escapes, true, nil
 }
 
-func rawPartsAreCanonical(s string, raw *rawParts) bool {
+// rawLayoutIsCanonical reports whether formatting the raw parts reproduces the
+// delimiters, authority, and drive letter of s unchanged.
+func rawLayoutIsCanonical(s string, raw *rawParts) bool {
 	if raw.hasQuery && raw.query == "" || raw.hasFragment && raw.fragment == "" {
 		return false
 	}
 	if raw.authority != "" && !isCanonicalAuthority(raw.authority) {
 		return false
 	}
-	if !isCanonicalPath(raw.path) || !isCanonicalComponent(raw.query) || !isCanonicalComponent(raw.fragment) {
-		return false
-	}
-	if !referenceAlreadyResolved(raw.scheme, raw.path) {
+	if hasUpperDrive(raw.path) || !referenceAlreadyResolved(raw.scheme, raw.path) {
 		return false
 	}
 	return authorityDelimiterIsCanonical(s, raw)
 }
 
+// countRawEscapes returns the number of path, query, and fragment bytes the
+// formatter escapes. It reports false if a component contains '%', whose
+// decoding would change the text.
+func countRawEscapes(raw *rawParts) (int, bool) {
+	path, ok := countEscapes(raw.path, true)
+	if !ok {
+		return 0, false
+	}
+	query, ok := countEscapes(raw.query, false)
+	if !ok {
+		return 0, false
+	}
+	fragment, ok := countEscapes(raw.fragment, false)
+	if !ok {
+		return 0, false
+	}
+	return path + query + fragment, true
+}
+
+func countEscapes(component string, isPath bool) (int, bool) {
+	pass := charClassUnreserved
+	if isPath {
+		pass |= charClassPathExtra
+	}
+	i, n := 0, 0
+	if len(component) >= swarMinLen {
+		i = passPrefix(component, isPath)
+	}
+	for ; i < len(component); i++ {
+		c := component[i]
+		if uriCharClass[c]&pass != 0 {
+			continue
+		}
+		if c == '%' {
+			return 0, false
+		}
+		n++
+	}
+	return n, true
+}
+
+func escapeRawParts(s string, raw *rawParts, escapes int) string {
+	b := make([]byte, 0, len(s)+2*escapes)
+	return bytesString(appendEscapedRawParts(b, s, raw))
+}
+
+func appendEscapedRawParts(b []byte, s string, raw *rawParts) []byte {
+	b = append(b, s[:raw.pathStart]...)
+	b = appendComponentFast(b, raw.path, true, false)
+	if raw.hasQuery {
+		b = append(b, '?')
+		b = appendComponentFast(b, raw.query, false, false)
+	}
+	if raw.hasFragment {
+		b = append(b, '#')
+		b = appendComponentFast(b, raw.fragment, false, false)
+	}
+	return b
+}
+
+func validateRawScheme(s string, raw *rawParts, strict bool) error {
+	if raw.scheme == "" {
+		if strict {
+			return uriErrorAt("parse", s, ErrMissingScheme, 0, ComponentScheme)
+		}
+		return nil
+	}
+	if !validScheme(raw.scheme) {
+		return uriErrorAt("parse", s, ErrInvalidScheme, invalidSchemeOffset(raw.scheme), ComponentScheme)
+	}
+	return nil
+}
+
 func authorityDelimiterIsCanonical(s string, raw *rawParts) bool {
 	hasAuthorityDelimiter := len(s) >= len(raw.scheme)+3 && s[len(raw.scheme)+1] == '/' && s[len(raw.scheme)+2] == '/'
 	if raw.scheme == schemeFile {
@@ -250,10 +410,8 @@ func parseCanonicalFileFast(s string) (URI, bool) {
 	if len(s) > fileURIPathStart+1 && s[fileURIPathStart+1] == '/' {
 		return "", false
 	}
-	for i := fileURIPathStart + 1; i < len(s); i++ {
-		if !canPassFast(s[i], true, false) {
-			return "", false
-		}
+	if fileURIPathStart+1+passPrefix(s[fileURIPathStart+1:], true) != len(s) {
+		return "", false
 	}
 	return URI(s), true
 }
@@ -273,14 +431,22 @@ func isCanonicalAuthority(authority string) bool {
 		}
 		hostport = after
 	}
-	if hostport != strings.ToLower(hostport) {
-		return false
-	}
 	colon := strings.LastIndexByte(hostport, ':')
 	if colon < 0 {
-		return isCanonicalAuthorityPass(hostport)
+		return isCanonicalHost(hostport)
 	}
-	return isCanonicalAuthorityPass(hostport[:colon]) && isCanonicalPortTail(hostport[colon:])
+	return isCanonicalHost(hostport[:colon]) && isCanonicalPortTail(hostport[colon:])
+}
+
+// isCanonicalHost reports whether host passes unescaped and is already
+// lowercase, as the formatter lowercases everything after userinfo.
+func isCanonicalHost(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if isUpperASCII(s[i]) || !canPassFast(s[i], false, true) {
+			return false
+		}
+	}
+	return true
 }
 
 func isCanonicalAuthorityPass(s string) bool {
@@ -304,15 +470,6 @@ func isCanonicalPortTail(s string) bool {
 	return true
 }
 
-func isCanonicalPath(path string) bool {
-	for i := 0; i < len(path); i++ {
-		if !canPassFast(path[i], true, false) {
-			return false
-		}
-	}
-	return true
-}
-
 func isCanonicalComponent(component string) bool {
 	for i := 0; i < len(component); i++ {
 		if !canPassFast(component[i], false, false) {
@@ -323,12 +480,10 @@ func isCanonicalComponent(component string) bool {
 }
 
 func referenceAlreadyResolved(scheme, path string) bool {
-	switch scheme {
-	case schemeHTTPS, schemeHTTP, schemeFile:
+	if isHierarchicalScheme(scheme) {
 		return path != "" && path[0] == '/'
-	default:
-		return true
 	}
+	return true
 }
 
 func schemeFix(scheme string, strict bool) string {
@@ -339,8 +494,7 @@ func schemeFix(scheme string, strict bool) string {
 }
 
 func referenceResolution(scheme, path string) string {
-	switch scheme {
-	case schemeHTTPS, schemeHTTP, schemeFile:
+	if isHierarchicalScheme(scheme) {
 		if path == "" {
 			return "/"
 		}
@@ -0,0 +1,174 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uritest
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.lsp.dev/uri"
+)
+
+// SchemaVersion is the version of the vector file returned by Corpus. It is
+// bumped when a section is added or changes shape.
+const SchemaVersion = 2
+
+//go:embed vectors.json
+var vectorsJSON []byte
+
+// Vectors is the conformance vector file generated from vscode-uri by
+// tools/genvectors. Each section records inputs and the results vscode-uri
+// produces for them, in the canonical form this module compares. Fields are
+// declared in the order tools/genvectors writes them.
+type Vectors struct {
+	Version            int            `json:"version"`
+	Source             string         `json:"source"`
+	Parse              []ParseVector  `json:"parse"`
+	Errors             []ErrorVector  `json:"errors"`
+	Paths              []PathVector   `json:"paths"`
+	With               []WithVector   `json:"with"`
+	From               []FromVector   `json:"from"`
+	File               []FileVector   `json:"file"`
+	NotebookCells      []CellVector   `json:"notebookCells"`
+	ParseOptions       []OptionVector `json:"parseOptions"`
+	GeneratedAt        string         `json:"generatedAt"`
+	Generator          string         `json:"generator"`
+	VscodeURIVersion   string         `json:"vscodeURIVersion"`
+	Contract           string         `json:"contract"`
+	ReferenceGenerated []string       `json:"referenceGenerated"`
+	Curated            []string       `json:"curated"`
+	Note               string         `json:"note"`
+}
+
+// ParseVector records how an input parses: the components of its canonical
+// form, both string forms, and the filesystem path on each platform.
+type ParseVector struct {
+	Name             string         `json:"name"`
+	Input            string         `json:"input"`
+	Components       uri.Components `json:"components"`
+	String           string         `json:"string"`
+	StringNoEncoding string         `json:"stringNoEncoding"`
+	FsPathPOSIX      string         `json:"fsPathPOSIX"`
+	FsPathWindows    string         `json:"fsPathWindows"`
+}
+
+// ErrorVector records an input that fails to parse. Error is the text of the
+// expected error sentinel, such as uri.ErrMissingScheme.Error(). Strict
+// vectors fail only with uri.ParseStrict.
+type ErrorVector struct {
+	Name   string `json:"name"`
+	Input  string `json:"input"`
+	Strict bool   `json:"strict"`
+	Error  string `json:"error"`
+}
+
+// PathVector records a path utility result. Op is one of "join", "resolve",
+// "dirname", "basename", and "extname"; Segments is empty for the last three.
+type PathVector struct {
+	Name     string   `json:"name"`
+	Op       string   `json:"op"`
+	URI      string   `json:"uri"`
+	Segments []string `json:"segments"`
+	Want     string   `json:"want"`
+}
+
+// WithVector records the result of URI.With. Throws is set instead of Want
+// when vscode-uri rejects the change.
+type WithVector struct {
+	Name   string     `json:"name"`
+	URI    string     `json:"uri"`
+	Change uri.Change `json:"change"`
+	Want   string     `json:"want"`
+	Throws bool       `json:"throws"`
+}
+
+// FromVector records the result of From. Throws is set instead of Want when
+// vscode-uri rejects the components.
+type FromVector struct {
+	Name       string         `json:"name"`
+	Components uri.Components `json:"components"`
+	Want       string         `json:"want"`
+	Throws     bool           `json:"throws"`
+}
+
+// FileVector records the result of FileFor. Platform is "posix" or
+// "windows".
+type FileVector struct {
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
+	Path     string `json:"path"`
+	Want     string `json:"want"`
+}
+
+// CellVector records a notebook cell URI and the notebook and handle it
+// encodes.
+type CellVector struct {
+	Name     string `json:"name"`
+	Notebook string `json:"notebook"`
+	Handle   int    `json:"handle"`
+	Cell     string `json:"cell"`
+}
+
+// OptionVector records the result of parsing Input with a configured
+// uri.Parser. Exactly one of String and Error is set.
+type OptionVector struct {
+	Name    string        `json:"name"`
+	Input   string        `json:"input"`
+	Options VectorOptions `json:"options"`
+	String  string        `json:"string"`
+	Error   string        `json:"error"`
+}
+
+// VectorOptions is the JSON form of the uri.ParseOptions an OptionVector
+// uses.
+type VectorOptions struct {
+	Strict            bool       `json:"strict"`
+	DisallowDrivePath bool       `json:"disallowDrivePath"`
+	DisallowUserinfo  bool       `json:"disallowUserinfo"`
+	Limits            uri.Limits `json:"limits"`
+	RejectControl     bool       `json:"rejectControl"`
+	Schemes           []string   `json:"schemes"`
+	RFC3986           bool       `json:"rfc3986"`
+}
+
+// Corpus returns the conformance vectors this module is tested against.
+// Each call returns a fresh copy that the caller may modify.
+//
+// Downstream tests can use the vectors as fixtures or fuzz seeds, for
+// example by adding every ParseVector.Input to a testing.F.
+func Corpus() *Vectors {
+	v, err := DecodeVectors(vectorsJSON)
+	if err != nil {
+		panic("uritest: embedded vectors: " + err.Error())
+	}
+	return v
+}
+
+// CorpusJSON returns the vector file as committed, for consumers that decode
+// it themselves.
+func CorpusJSON() []byte {
+	return bytes.Clone(vectorsJSON)
+}
+
+// DecodeVectors decodes a vector file of version SchemaVersion, rejecting
+// sections and fields Vectors does not know.
+func DecodeVectors(data []byte) (*Vectors, error) {
+	var v Vectors
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after vector file")
+	}
+	if v.Version != SchemaVersion {
+		return nil, fmt.Errorf("vector file version %d, want %d", v.Version, SchemaVersion)
+	}
+	return &v, nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uritest
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"go.lsp.dev/uri"
+)
+
+// Generator produces URIs and filesystem paths shaped like those language
+// servers see: POSIX, Windows drive, and UNC paths, module cache paths with
+// the "!" case encoding, Unicode segments, and non-file URIs with
+// authorities, queries, and fragments.
+//
+// A Generator is deterministic: the same seed or bytes always yield the same
+// sequence, so generated values are suitable as testing.F seeds and
+// failures are reproducible. A Generator is not safe for concurrent use.
+type Generator struct {
+	next func() uint64
+}
+
+// NewGenerator returns a Generator drawing from a PCG source with the given
+// seed.
+func NewGenerator(seed uint64) *Generator {
+	r := rand.New(rand.NewPCG(seed, 0x75726974657374))
+	return &Generator{next: r.Uint64}
+}
+
+// NewGeneratorFromBytes returns a Generator that draws one choice from each
+// byte of data and zero once data runs out. Inside a fuzz target it lets the
+// fuzzer explore the generated shapes:
+//
+//	f.Fuzz(func(t *testing.T, data []byte) {
+//		u := uritest.NewGeneratorFromBytes(data).URI(true)
+//		...
+//	})
+func NewGeneratorFromBytes(data []byte) *Generator {
+	return &Generator{next: func() uint64 {
+		if len(data) == 0 {
+			return 0
+		}
+		c := data[0]
+		data = data[1:]
+		return uint64(c)
+	}}
+}
+
+// Intn returns a number in [0, n) from the generator's stream, for callers
+// that choose between generated shapes. It panics if n <= 0.
+func (g *Generator) Intn(n int) int {
+	if n <= 0 {
+		panic("uritest: Intn argument must be positive")
+	}
+	return int(g.next() % uint64(n))
+}
+
+func (g *Generator) pick(s ...string) string {
+	return s[g.Intn(len(s))]
+}
+
+// Segment pieces. Plain pieces survive a StringNoEncoding round trip;
+// reserved pieces are delimiters, escapes, or sub-delimiters once decoded.
+var (
+	plainPieces = []string{
+		"a", "b", "Z", "go", "main", "0", "42", "-", "_", "~", " ", "x y",
+		"é", "ü", "日本", "Ω", "🙂", "ﬀ", "e\u0301", ".go", ".ts",
+	}
+	reservedPieces = []string{
+		"#", "?", "%", "%41", "%2F", "@", ":", "&", "=", "+", ";", ",", "!",
+		"$", "'", "(", ")", "*", "[", "]",
+	}
+)
+
+// Segment returns a path segment that is never empty, ".", or "..", and
+// never contains a slash or backslash. It contains reserved characters and
+// literal escapes such as "%41" only when reserved is set.
+func (g *Generator) Segment(reserved bool) string {
+	var b strings.Builder
+	for n := 1 + g.Intn(3); n > 0; n-- {
+		if reserved && g.Intn(3) == 0 {
+			b.WriteString(g.pick(reservedPieces...))
+		} else {
+			b.WriteString(g.pick(plainPieces...))
+		}
+	}
+	s := b.String()
+	if strings.Trim(s, ".") == "" {
+		s = "dot" + s
+	}
+	return s
+}
+
+// RelativePath returns one to four segments joined by slashes.
+func (g *Generator) RelativePath(reserved bool) string {
+	return g.segments("/", reserved)
+}
+
+func (g *Generator) segments(sep string, reserved bool) string {
+	parts := make([]string, 1+g.Intn(4))
+	for i := range parts {
+		parts[i] = g.Segment(reserved)
+	}
+	return strings.Join(parts, sep)
+}
+
+// POSIXPath returns an absolute POSIX path without a trailing slash whose
+// first segment never looks like a drive letter.
+func (g *Generator) POSIXPath(reserved bool) string {
+	return "/" + g.pick("home", "tmp", "Users", "src") + "/" + g.segments("/", reserved)
+}
+
+// WindowsPath returns a drive path with an upper or lowercase drive letter
+// and either separator.
+func (g *Generator) WindowsPath() string {
+	sep := g.pick(`\`, "/")
+	return g.pick("c", "C", "d", "Z") + ":" + sep + g.segments(sep, false)
+}
+
+// UNCPath returns a \\server\share path with at least one segment below the
+// share.
+func (g *Generator) UNCPath() string {
+	server := g.pick("server", "SERVER", "fs-01", "host.example.com")
+	return `\\` + server + `\` + g.pick("share", "Share$", "c$") + `\` + g.segments(`\`, false)
+}
+
+// ModCachePath returns the path of a file inside an extracted module in a
+// POSIX or Windows module cache, with the cache's "!" encoding of uppercase
+// letters, and the platform the path belongs to.
+func (g *Generator) ModCachePath() (path string, platform uri.Platform) {
+	module := g.pick("github.com/!burnt!sushi/toml", "golang.org/x/tools", "example.com/!azure/sdk/v2", "gopkg.in/yaml.v3")
+	version := g.pick("v1.2.3", "v0.0.0-20240101000000-abcdef123456", "v2.0.0+incompatible", "v1.0.0-rc.1")
+	rel := g.segments("/", false)
+	if g.Intn(2) == 0 {
+		return "/home/u/go/pkg/mod/" + module + "@" + version + "/" + rel, uri.PlatformPOSIX
+	}
+	rel = strings.ReplaceAll(module+"@"+version+"/"+rel, "/", `\`)
+	return `C:\Users\U\go\pkg\mod\` + rel, uri.PlatformWindows
+}
+
+// Path returns a POSIX, Windows drive, UNC, or module cache path and the
+// platform it belongs to. POSIX paths may contain reserved characters.
+func (g *Generator) Path() (path string, platform uri.Platform) {
+	switch g.Intn(4) {
+	case 0:
+		return g.POSIXPath(g.Intn(2) == 0), uri.PlatformPOSIX
+	case 1:
+		return g.WindowsPath(), uri.PlatformWindows
+	case 2:
+		return g.UNCPath(), uri.PlatformWindows
+	default:
+		return g.ModCachePath()
+	}
+}
+
+// URI returns a URI with a rooted path that has no trailing slash, built
+// either with uri.FileFor from a generated path or with uri.From from
+// generated components. Unless reserved is set, no decoded component
+// contains delimiters or escapes, so the URI also survives a
+// StringNoEncoding round trip.
+//
+// Fuzz seeds take strings, so add string(g.URI(true)) to a testing.F.
+func (g *Generator) URI(reserved bool) uri.URI {
+	if g.Intn(2) == 0 {
+		path, platform := g.Path()
+		if !reserved && platform == uri.PlatformPOSIX {
+			path = g.POSIXPath(false)
+		}
+		return uri.FileFor(platform, path)
+	}
+	c := uri.Components{
+		Scheme:    g.pick("https", "untitled", "vscode-remote", "foo", "git"),
+		Authority: g.pick("", "", "example.com", "user@host:8080", "wsl+Ubuntu"),
+		Path:      g.POSIXPath(reserved && g.Intn(2) == 0),
+	}
+	if g.Intn(3) == 0 {
+		c.Query = g.pick("q=1", "a=1&b=2", "x y", "ref=main")
+		if reserved && g.Intn(2) == 0 {
+			c.Query += g.pick(reservedPieces...)
+		}
+	}
+	if g.Intn(3) == 0 {
+		c.Fragment = g.pick("L10", "section-2", "é")
+		if reserved && g.Intn(2) == 0 {
+			c.Fragment += g.pick(reservedPieces...)
+		}
+	}
+	u, err := uri.From(c)
+	if err != nil {
+		panic("uritest: generated invalid components: " + err.Error())
+	}
+	return u
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package uritest provides fixtures, generators, and assertions for testing
+// code that handles URIs across platforms.
+//
+// Corpus exposes the conformance vectors generated from vscode-uri that this
+// module is tested against. Generator produces deterministic POSIX, Windows,
+// UNC, and module cache paths and URIs for table tests and fuzz seeds.
+// AssertCanonical and AssertSameFile report failures through testing.TB.
+package uritest // import "go.lsp.dev/uri/uritest"
+
+import (
+	"strings"
+	"testing"
+
+	"go.lsp.dev/uri"
+)
+
+// AssertCanonical reports an error through t unless u is in canonical form:
+// it parses back to itself and From rebuilds it from its components. It
+// reports whether u is canonical.
+//
+// URIs built by this module's constructors are always canonical. A URI that
+// is not was usually converted from a string without uri.Parse.
+func AssertCanonical(t testing.TB, u uri.URI) bool {
+	t.Helper()
+	v, err := uri.Parse(string(u))
+	switch {
+	case err != nil:
+		t.Errorf("uri %q is not canonical: Parse() error = %v", u, err)
+		return false
+	case v != u:
+		t.Errorf("uri %q is not canonical: Parse() = %q", u, v)
+		return false
+	}
+	if v, err := uri.From(u.Components()); err != nil || v != u {
+		t.Errorf("uri %q is not canonical: From(Components()) = %q, %v", u, v, err)
+		return false
+	}
+	return true
+}
+
+// AssertSameFile reports an error through t unless a and b are file URIs
+// naming the same file on platform. It reports whether they do.
+//
+// The URIs are compared by their filesystem paths, so query and fragment are
+// ignored. Windows paths compare case-insensitively, as on NTFS; POSIX paths
+// compare exactly.
+func AssertSameFile(t testing.TB, a, b uri.URI, platform uri.Platform) bool {
+	t.Helper()
+	for _, u := range []uri.URI{a, b} {
+		if !u.IsFile() {
+			t.Errorf("uri %q is not a file URI", u)
+			return false
+		}
+	}
+	pa := uri.FsPathFor(a, platform, false)
+	pb := uri.FsPathFor(b, platform, false)
+	same := pa == pb
+	if platform == uri.PlatformWindows {
+		same = strings.EqualFold(pa, pb)
+	}
+	if !same {
+		t.Errorf("uris %q and %q name different files: %q and %q", a, b, pa, pb)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,263 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uritest
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.lsp.dev/uri"
+)
+
+// recorder is a testing.TB that records errors instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCorpus(t *testing.T) {
+	t.Parallel()
+
+	v := Corpus()
+	if v.Version != SchemaVersion {
+		t.Fatalf("Corpus().Version = %d, want %d", v.Version, SchemaVersion)
+	}
+	sections := map[string]int{
+		"parse":         len(v.Parse),
+		"errors":        len(v.Errors),
+		"paths":         len(v.Paths),
+		"with":          len(v.With),
+		"from":          len(v.From),
+		"file":          len(v.File),
+		"notebookCells": len(v.NotebookCells),
+		"parseOptions":  len(v.ParseOptions),
+	}
+	for name, n := range sections {
+		if n == 0 {
+			t.Fatalf("Corpus() section %s is empty", name)
+		}
+	}
+	for _, p := range v.Parse {
+		AssertCanonical(t, uri.URI(p.String))
+	}
+
+	v.Parse[0].Input = "changed"
+	if Corpus().Parse[0].Input == "changed" {
+		t.Fatal("Corpus() returned shared vectors")
+	}
+
+	data, err := os.ReadFile("vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := CorpusJSON(); !slices.Equal(got, data) {
+		t.Fatal("CorpusJSON() differs from vectors.json")
+	}
+}
+
+func TestDecodeVectors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		data    string
+		wantErr string
+	}{
+		"success: minimal file": {
+			data: `{"version": 2, "parse": []}`,
+		},
+		"error: other version": {
+			data:    `{"version": 1}`,
+			wantErr: "version 1, want 2",
+		},
+		"error: unknown section": {
+			data:    `{"version": 2, "change": []}`,
+			wantErr: "change",
+		},
+		"error: unknown vector field": {
+			data:    `{"version": 2, "with": [{"name": "x", "uri": "file:///", "want": "", "error": "x"}]}`,
+			wantErr: "error",
+		},
+		"error: unknown nested field": {
+			data:    `{"version": 2, "from": [{"name": "x", "components": {"host": "x"}}]}`,
+			wantErr: "host",
+		},
+		"error: trailing second value": {
+			data:    `{"version": 2} {}`,
+			wantErr: "unexpected data",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := DecodeVectors([]byte(tt.data))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("DecodeVectors() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("DecodeVectors() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	t.Parallel()
+
+	draw := func(g *Generator) []string {
+		var out []string
+		for range 50 {
+			path, platform := g.Path()
+			out = append(out, string(g.URI(true)), string(g.URI(false)), path, fmt.Sprint(platform))
+		}
+		return out
+	}
+	if a, b := draw(NewGenerator(1)), draw(NewGenerator(1)); !slices.Equal(a, b) {
+		t.Fatal("NewGenerator(1) is not deterministic")
+	}
+	if a, b := draw(NewGenerator(1)), draw(NewGenerator(2)); slices.Equal(a, b) {
+		t.Fatal("NewGenerator(1) and NewGenerator(2) generate the same values")
+	}
+	if a, b := draw(NewGeneratorFromBytes([]byte{3, 1, 4})), draw(NewGeneratorFromBytes([]byte{3, 1, 4})); !slices.Equal(a, b) {
+		t.Fatal("NewGeneratorFromBytes is not deterministic")
+	}
+
+	g := NewGenerator(0)
+	for range 1000 {
+		AssertCanonical(t, g.URI(true))
+		if s := g.Segment(true); s == "." || s == ".." || strings.ContainsAny(s, `/\`) {
+			t.Fatalf("Segment() = %q", s)
+		}
+		path, platform := g.Path()
+		if u := uri.FileFor(platform, path); !AssertCanonical(t, u) || !u.IsFile() {
+			t.Fatalf("FileFor(%v, %q) = %q", platform, path, u)
+		}
+		if u := g.URI(false); u.StringNoEncoding() != "" {
+			if v := uri.MustParse(u.StringNoEncoding()); v != u {
+				t.Fatalf("URI(false) = %q does not survive StringNoEncoding: %q", u, v)
+			}
+		}
+	}
+	if p := NewGenerator(0).UNCPath(); !strings.HasPrefix(p, `\\`) {
+		t.Fatalf("UNCPath() = %q", p)
+	}
+	if p, _ := NewGenerator(0).ModCachePath(); !strings.Contains(p, "@v") {
+		t.Fatalf("ModCachePath() = %q", p)
+	}
+}
+
+func TestAssertCanonical(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		uri     uri.URI
+		wantErr string
+	}{
+		"success: parsed": {
+			uri: uri.MustParse("file:///C:/a b"),
+		},
+		"success: file": {
+			uri: uri.FileFor(uri.PlatformWindows, `\\server\share\日本.go`),
+		},
+		"error: unescaped space": {
+			uri:     "file:///a b",
+			wantErr: `Parse() = "file:///a%20b"`,
+		},
+		"error: uppercase drive": {
+			uri:     "file:///C%3A/a",
+			wantErr: `Parse() = "file:///c%3A/a"`,
+		},
+		"error: invalid scheme": {
+			uri:     "fäil:path",
+			wantErr: "Parse() error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := &recorder{}
+			ok := AssertCanonical(r, tt.uri)
+			if ok != (tt.wantErr == "") {
+				t.Fatalf("AssertCanonical(%q) = %t, errors %q", tt.uri, ok, r.errors)
+			}
+			if tt.wantErr != "" && (len(r.errors) != 1 || !strings.Contains(r.errors[0], tt.wantErr)) {
+				t.Fatalf("AssertCanonical(%q) errors = %q, want one containing %q", tt.uri, r.errors, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAssertSameFile(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		a, b     uri.URI
+		platform uri.Platform
+		wantErr  string
+	}{
+		"success: drive letter case": {
+			a:        uri.FileFor(uri.PlatformWindows, `C:\src\a.go`),
+			b:        uri.MustParse("file:///c%3A/src/a.go"),
+			platform: uri.PlatformWindows,
+		},
+		"success: windows path case": {
+			a:        uri.FileFor(uri.PlatformWindows, `c:\Src\A.go`),
+			b:        uri.FileFor(uri.PlatformWindows, `c:/src/a.go`),
+			platform: uri.PlatformWindows,
+		},
+		"success: query and fragment ignored": {
+			a:        uri.MustParse("file:///home/a.go?x#L1"),
+			b:        uri.MustParse("file:///home/a.go"),
+			platform: uri.PlatformPOSIX,
+		},
+		"success: UNC": {
+			a:        uri.FileFor(uri.PlatformWindows, `\\SERVER\share\a.go`),
+			b:        uri.MustParse("file://server/share/a.go"),
+			platform: uri.PlatformWindows,
+		},
+		"error: posix path case": {
+			a:        uri.MustParse("file:///home/A.go"),
+			b:        uri.MustParse("file:///home/a.go"),
+			platform: uri.PlatformPOSIX,
+			wantErr:  "name different files",
+		},
+		"error: different server": {
+			a:        uri.MustParse("file://a/share/x"),
+			b:        uri.MustParse("file://b/share/x"),
+			platform: uri.PlatformWindows,
+			wantErr:  "name different files",
+		},
+		"error: not a file": {
+			a:        uri.MustParse("untitled:a.go"),
+			b:        uri.MustParse("file:///a.go"),
+			platform: uri.PlatformPOSIX,
+			wantErr:  `"untitled:a.go" is not a file URI`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := &recorder{}
+			ok := AssertSameFile(r, tt.a, tt.b, tt.platform)
+			if ok != (tt.wantErr == "") {
+				t.Fatalf("AssertSameFile(%q, %q) = %t, errors %q", tt.a, tt.b, ok, r.errors)
+			}
+			if tt.wantErr != "" && (len(r.errors) != 1 || !strings.Contains(r.errors[0], tt.wantErr)) {
+				t.Fatalf("AssertSameFile(%q, %q) errors = %q, want one containing %q", tt.a, tt.b, r.errors, tt.wantErr)
+			}
+		})
+	}
+}
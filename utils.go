@@ -7,6 +7,9 @@ package uri
 import "strings"
 
 // JoinPath joins URI path segments using Node path.posix semantics.
+//
+// Results for schemes registered as hierarchical, such as file and https, stay
+// rooted at "/", so joining onto an authority-only URI yields a valid path.
 func JoinPath(u URI, segments ...string) (URI, error) {
 	paths := make([]string, 0, len(segments)+1)
 	paths = append(paths, u.Path())
@@ -33,6 +36,9 @@ func ResolvePath(u URI, segments ...string) (URI, error) {
 }
 
 // Dirname returns a URI with its path replaced by Node path.posix.dirname.
+//
+// For schemes registered as hierarchical the parent of a relative single
+// segment path is "/" rather than the empty path.
 func Dirname(u URI) URI {
 	path := u.Path()
 	if path == "" || path == "/" {
@@ -58,6 +64,9 @@ func Extname(u URI) string {
 func withPath(u URI, path string) (URI, error) {
 	c := u.Components()
 	c.Path = path
+	if (path == "" || path[0] != '/') && isHierarchicalScheme(c.Scheme) {
+		c.Path = "/" + path
+	}
 	return newURI(&c, false, "with path", u.String())
 }
 
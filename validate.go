@@ -0,0 +1,210 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Rule names reported by Error.Rule for the rules of this package.
+const (
+	RuleAllowSchemes        = "AllowSchemes"
+	RuleRequireAuthority    = "RequireAuthority"
+	RuleForbidQuery         = "ForbidQuery"
+	RuleRequireAbsolutePath = "RequireAbsolutePath"
+	RuleMaxLen              = "MaxLen"
+	RuleRequireFileBacked   = "RequireFileBacked"
+)
+
+// Rule is a named constraint on a URI, checked by a Validator.
+type Rule struct {
+	name  string
+	check func(u URI, raw *rawParts) error
+}
+
+// Name returns the rule name reported in Error.Rule.
+func (r Rule) Name() string {
+	return r.name
+}
+
+// NewRule returns a rule named name that rejects a URI when check returns a
+// non-nil error. Validator wraps that error in an *Error carrying the rule
+// name unless it already is one.
+func NewRule(name string, check func(u URI) error) Rule {
+	return Rule{name: name, check: func(u URI, _ *rawParts) error {
+		return check(u)
+	}}
+}
+
+// AllowSchemes returns a rule accepting only URIs whose scheme is one of
+// schemes, compared case-insensitively. Failures wrap ErrSchemeNotAllowed.
+//
+// AllowSchemes("file") rejects schemes registered as FileBacked with
+// RegisterScheme; RequireFileBacked accepts them as well.
+func AllowSchemes(schemes ...string) Rule {
+	allowed := make([]string, len(schemes))
+	for i, scheme := range schemes {
+		allowed[i] = strings.ToLower(scheme)
+	}
+	return Rule{name: RuleAllowSchemes, check: func(u URI, raw *rawParts) error {
+		if slices.Contains(allowed, strings.ToLower(raw.scheme)) {
+			return nil
+		}
+		return uriErrorAt("validate", string(u), ErrSchemeNotAllowed, 0, ComponentScheme)
+	}}
+}
+
+// RequireAuthority returns a rule rejecting URIs with an empty authority,
+// such as file:///path or untitled:Untitled-1. Failures wrap
+// ErrMissingAuthority.
+func RequireAuthority() Rule {
+	return Rule{name: RuleRequireAuthority, check: func(u URI, raw *rawParts) error {
+		if raw.authority != "" {
+			return nil
+		}
+		return uriErrorAt("validate", string(u), ErrMissingAuthority, raw.authorityStart, ComponentAuthority)
+	}}
+}
+
+// ForbidQuery returns a rule rejecting URIs with a query. Failures wrap
+// ErrQueryNotAllowed.
+func ForbidQuery() Rule {
+	return Rule{name: RuleForbidQuery, check: func(u URI, raw *rawParts) error {
+		if !raw.hasQuery {
+			return nil
+		}
+		return uriErrorAt("validate", string(u), ErrQueryNotAllowed, raw.pathEnd+1, ComponentQuery)
+	}}
+}
+
+// RequireAbsolutePath returns a rule rejecting URIs whose path does not begin
+// with a slash, such as untitled:Untitled-1 or mailto:a@example.com. Failures
+// wrap ErrRelativePath.
+func RequireAbsolutePath() Rule {
+	return Rule{name: RuleRequireAbsolutePath, check: func(u URI, raw *rawParts) error {
+		if strings.HasPrefix(raw.path, "/") {
+			return nil
+		}
+		return uriErrorAt("validate", string(u), ErrRelativePath, raw.pathStart, ComponentPath)
+	}}
+}
+
+// RequireFileBacked returns a rule accepting only URIs whose scheme names
+// filesystem locations: file and the schemes registered as FileBacked with
+// RegisterScheme. Failures wrap ErrNotFileBacked.
+func RequireFileBacked() Rule {
+	return Rule{name: RuleRequireFileBacked, check: func(u URI, raw *rawParts) error {
+		if isFileBackedScheme(raw.scheme) {
+			return nil
+		}
+		return uriErrorAt("validate", string(u), ErrNotFileBacked, 0, ComponentScheme)
+	}}
+}
+
+// MaxLen returns a rule rejecting URIs whose canonical string is longer than
+// n bytes. Failures wrap ErrTooLong and point at the first byte past n.
+// MaxLen panics if n is negative.
+func MaxLen(n int) Rule {
+	if n < 0 {
+		panic("uri: negative MaxLen")
+	}
+	return Rule{name: RuleMaxLen, check: func(u URI, raw *rawParts) error {
+		if len(u) <= n {
+			return nil
+		}
+		return uriErrorAt("validate", string(u), ErrTooLong, n, componentAt(raw, n))
+	}}
+}
+
+// Validator checks URIs against a fixed list of rules, for example the
+// constraints of a configuration setting. A Validator is safe for concurrent
+// use.
+//
+// A type whose values must satisfy a Validator can use it from its
+// UnmarshalText method, so that JSON and other text decoding enforce the
+// rules:
+//
+//	var rootValidator = uri.NewValidator(nil, uri.AllowSchemes("file"), uri.ForbidQuery())
+//
+//	type Root uri.URI
+//
+//	func (r *Root) UnmarshalText(text []byte) error {
+//		u, err := rootValidator.ParseBytes(text)
+//		if err != nil {
+//			return err
+//		}
+//		*r = Root(u)
+//		return nil
+//	}
+//
+// Define such a type from URI rather than embedding URI, whose promoted
+// decoding methods would take precedence over UnmarshalText.
+type Validator struct {
+	parser *Parser
+	rules  []Rule
+}
+
+// NewValidator returns a Validator checking rules in order. Parse and
+// ParseBytes parse with p, so its ParseOptions, such as Limits, apply to
+// decoded settings; a nil p parses like URI.UnmarshalText.
+func NewValidator(p *Parser, rules ...Rule) *Validator {
+	if p == nil {
+		p = defaultParser
+	}
+	return &Validator{parser: p, rules: slices.Clone(rules)}
+}
+
+// Validate checks u against the rules in order and returns the first
+// violation, or nil if u satisfies every rule. The error is an *Error whose
+// Rule field names the violated rule and whose Input is the canonical u.
+//
+// An *Error returned by a rule is copied, not modified, and keeps its Rule
+// when set, so a rule that runs another Validator reports the inner rule.
+func (v *Validator) Validate(u URI) error {
+	raw := splitRaw(string(u))
+	for _, r := range v.rules {
+		err := r.check(u, &raw)
+		if err == nil {
+			continue
+		}
+		var e *Error
+		if !errors.As(err, &e) {
+			return &Error{Op: "validate", Input: string(u), Err: err, Rule: r.name}
+		}
+		c := *e
+		if c.Rule == "" {
+			c.Rule = r.name
+		}
+		return &c
+	}
+	return nil
+}
+
+// Parse parses s with the Validator's Parser and validates the result. Parse
+// errors are returned unchanged, with an empty Rule.
+func (v *Validator) Parse(s string) (URI, error) {
+	u, err := v.parser.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	if err := v.Validate(u); err != nil {
+		return "", err
+	}
+	return u, nil
+}
+
+// ParseBytes is like Parse but takes a byte slice.
+func (v *Validator) ParseBytes(b []byte) (URI, error) {
+	u, err := v.parser.ParseBytes(b)
+	if err != nil {
+		return "", err
+	}
+	if err := v.Validate(u); err != nil {
+		return "", err
+	}
+	return u, nil
+}
@@ -0,0 +1,311 @@
+// Copyright 2026 The Go Language Server Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidatorValidate(t *testing.T) {
+	t.Parallel()
+
+	registerTestScheme(t, "test-validate-disk", SchemeInfo{FileBacked: true})
+	tests := map[string]struct {
+		rules         []Rule
+		uri           string
+		wantErr       error
+		wantRule      string
+		wantOffset    int
+		wantComponent string
+	}{
+		"success: no rules": {
+			uri: "foo:bar?q",
+		},
+		"success: all rules": {
+			rules: []Rule{AllowSchemes("HTTPS", "file"), RequireAuthority(), ForbidQuery(), RequireAbsolutePath(), MaxLen(30)},
+			uri:   "https://example.com/a#frag",
+		},
+		"success: file scheme": {
+			rules: []Rule{AllowSchemes("file"), RequireAbsolutePath(), ForbidQuery()},
+			uri:   "file:///home/me/go",
+		},
+		"success: max length inclusive": {
+			rules: []Rule{MaxLen(len("file:///a"))},
+			uri:   "file:///a",
+		},
+		"success: file-backed": {
+			rules: []Rule{RequireFileBacked()},
+			uri:   "file:///a",
+		},
+		"success: registered file-backed scheme": {
+			rules: []Rule{RequireFileBacked()},
+			uri:   "test-validate-disk://host/a",
+		},
+		"error: scheme not allowed": {
+			rules:         []Rule{AllowSchemes("file")},
+			uri:           "untitled:Untitled-1",
+			wantErr:       ErrSchemeNotAllowed,
+			wantRule:      RuleAllowSchemes,
+			wantOffset:    0,
+			wantComponent: ComponentScheme,
+		},
+		"error: no schemes allowed": {
+			rules:         []Rule{AllowSchemes()},
+			uri:           "file:///a",
+			wantErr:       ErrSchemeNotAllowed,
+			wantRule:      RuleAllowSchemes,
+			wantOffset:    0,
+			wantComponent: ComponentScheme,
+		},
+		"error: not file-backed": {
+			rules:         []Rule{RequireFileBacked()},
+			uri:           "untitled:Untitled-1",
+			wantErr:       ErrNotFileBacked,
+			wantRule:      RuleRequireFileBacked,
+			wantOffset:    0,
+			wantComponent: ComponentScheme,
+		},
+		"error: missing authority": {
+			rules:         []Rule{RequireAuthority()},
+			uri:           "file:///a",
+			wantErr:       ErrMissingAuthority,
+			wantRule:      RuleRequireAuthority,
+			wantOffset:    7,
+			wantComponent: ComponentAuthority,
+		},
+		"error: query": {
+			rules:         []Rule{ForbidQuery()},
+			uri:           "https://example.com/a?x=1#f",
+			wantErr:       ErrQueryNotAllowed,
+			wantRule:      RuleForbidQuery,
+			wantOffset:    22,
+			wantComponent: ComponentQuery,
+		},
+		"error: relative path": {
+			rules:         []Rule{RequireAbsolutePath()},
+			uri:           "untitled:Untitled-1",
+			wantErr:       ErrRelativePath,
+			wantRule:      RuleRequireAbsolutePath,
+			wantOffset:    9,
+			wantComponent: ComponentPath,
+		},
+		"error: too long": {
+			rules:         []Rule{MaxLen(20)},
+			uri:           "https://example.com/a?x=1",
+			wantErr:       ErrTooLong,
+			wantRule:      RuleMaxLen,
+			wantOffset:    20,
+			wantComponent: ComponentPath,
+		},
+		"error: first violated rule wins": {
+			rules:         []Rule{ForbidQuery(), AllowSchemes("file")},
+			uri:           "https://example.com/?q",
+			wantErr:       ErrQueryNotAllowed,
+			wantRule:      RuleForbidQuery,
+			wantOffset:    21,
+			wantComponent: ComponentQuery,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u := MustParse(tt.uri)
+			err := NewValidator(nil, tt.rules...).Validate(u)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate(%q) error = %v, want %v", u, err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				return
+			}
+			var uerr *Error
+			if !errors.As(err, &uerr) {
+				t.Fatalf("Validate(%q) error = %T, want *Error", u, err)
+			}
+			if uerr.Op != "validate" || uerr.Input != string(u) || uerr.Rule != tt.wantRule {
+				t.Fatalf("Validate(%q) error = {Op: %q, Input: %q, Rule: %q}, want validate, %q, %q", u, uerr.Op, uerr.Input, uerr.Rule, u, tt.wantRule)
+			}
+			if uerr.Offset != tt.wantOffset || uerr.Component != tt.wantComponent {
+				t.Fatalf("Validate(%q) position = %d in %q, want %d in %q", u, uerr.Offset, uerr.Component, tt.wantOffset, tt.wantComponent)
+			}
+		})
+	}
+}
+
+func TestNewRule(t *testing.T) {
+	t.Parallel()
+
+	errPort := errors.New("port is not allowed")
+	noPort := NewRule("NoPort", func(u URI) error {
+		if strings.Contains(u.Authority(), ":") {
+			return errPort
+		}
+		return nil
+	})
+	if got := noPort.Name(); got != "NoPort" {
+		t.Fatalf("Name() = %q, want NoPort", got)
+	}
+	v := NewValidator(nil, AllowSchemes("https"), noPort)
+	if err := v.Validate(MustParse("https://example.com/")); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	err := v.Validate(MustParse("https://example.com:8443/"))
+	var uerr *Error
+	if !errors.Is(err, errPort) || !errors.As(err, &uerr) || uerr.Rule != "NoPort" {
+		t.Fatalf("Validate() error = %#v, want *Error wrapping errPort with rule NoPort", err)
+	}
+	if want := `validate NoPort "https://example.com:8443/": port is not allowed`; err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestValidatorValidateCopiesError(t *testing.T) {
+	t.Parallel()
+
+	shared := &Error{Op: "check", Input: "x", Err: ErrQueryNotAllowed}
+	rule := NewRule("Shared", func(URI) error { return shared })
+	err := NewValidator(nil, rule).Validate(MustParse("file:///a?q"))
+	var uerr *Error
+	if !errors.As(err, &uerr) || uerr == shared || uerr.Rule != "Shared" {
+		t.Fatalf("Validate() error = %#v, want a copy with rule Shared", err)
+	}
+	if shared.Rule != "" {
+		t.Fatalf("Validate() set the rule of the returned error to %q", shared.Rule)
+	}
+
+	inner := NewValidator(nil, ForbidQuery())
+	outer := NewValidator(nil, NewRule("Root", func(u URI) error { return inner.Validate(u) }))
+	err = outer.Validate(MustParse("file:///a?q"))
+	if !errors.Is(err, ErrQueryNotAllowed) || !errors.As(err, &uerr) || uerr.Rule != RuleForbidQuery {
+		t.Fatalf("Validate() error = %#v, want the inner rule %s", err, RuleForbidQuery)
+	}
+}
+
+func TestMaxLenNegative(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MaxLen(-1) did not panic")
+		}
+	}()
+	MaxLen(-1)
+}
+
+func TestValidatorParse(t *testing.T) {
+	t.Parallel()
+
+	v := NewValidator(nil, AllowSchemes("file"), ForbidQuery())
+	tests := map[string]struct {
+		input    string
+		want     URI
+		wantErr  error
+		wantRule string
+	}{
+		"success: canonicalized": {
+			input: "file:///C:/a b",
+			want:  "file:///c%3A/a%20b",
+		},
+		"success: empty scheme falls back to file": {
+			input: "/home/me",
+			want:  "file:///home/me",
+		},
+		"error: parse error has no rule": {
+			input:   "fäil:path",
+			wantErr: ErrInvalidScheme,
+		},
+		"error: rule violation": {
+			input:    "file:///a?b",
+			wantErr:  ErrQueryNotAllowed,
+			wantRule: RuleForbidQuery,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for _, parse := range []func(string) (URI, error){
+				v.Parse,
+				func(s string) (URI, error) { return v.ParseBytes([]byte(s)) },
+			} {
+				got, err := parse(tt.input)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				if got != tt.want {
+					t.Fatalf("Parse(%q) = %q, want %q", tt.input, got, tt.want)
+				}
+				var uerr *Error
+				if err != nil && (!errors.As(err, &uerr) || uerr.Rule != tt.wantRule) {
+					t.Fatalf("Parse(%q) error = %#v, want *Error with rule %q", tt.input, err, tt.wantRule)
+				}
+			}
+		})
+	}
+}
+
+func TestValidatorParser(t *testing.T) {
+	t.Parallel()
+
+	v := NewValidator(NewParser(ParseOptions{Strict: true, Limits: Limits{MaxLength: 16}}), ForbidQuery())
+	tests := map[string]struct {
+		input   string
+		want    URI
+		wantErr error
+	}{
+		"success: within limits":      {input: "file:///a", want: "file:///a"},
+		"error: parser limit":         {input: "file:///" + strings.Repeat("a", 16), wantErr: ErrTooLong},
+		"error: strict parser scheme": {input: "/a", wantErr: ErrMissingScheme},
+		"error: rule after parser":    {input: "file:///a?q", wantErr: ErrQueryNotAllowed},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := v.Parse(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// settingRoot is a URI setting validated while decoding, as described in the
+// Validator documentation.
+type settingRoot URI
+
+var settingRootValidator = NewValidator(nil, AllowSchemes("file"), RequireAbsolutePath(), ForbidQuery())
+
+func (r *settingRoot) UnmarshalText(text []byte) error {
+	u, err := settingRootValidator.ParseBytes(text)
+	if err != nil {
+		return err
+	}
+	*r = settingRoot(u)
+	return nil
+}
+
+func TestValidatorUnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	var settings struct {
+		Roots []settingRoot `json:"roots"`
+	}
+	if err := json.Unmarshal([]byte(`{"roots": ["file:///home/me", "/tmp/x"]}`), &settings); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(settings.Roots) != 2 || settings.Roots[1] != "file:///tmp/x" {
+		t.Fatalf("Unmarshal() roots = %v", settings.Roots)
+	}
+
+	err := json.Unmarshal([]byte(`{"roots": ["https://example.com/"]}`), &settings)
+	var uerr *Error
+	if !errors.Is(err, ErrSchemeNotAllowed) || !errors.As(err, &uerr) || uerr.Rule != RuleAllowSchemes {
+		t.Fatalf("Unmarshal() error = %v, want AllowSchemes violation", err)
+	}
+}
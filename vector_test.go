@@ -2,58 +2,25 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-package uri
+package uri_test
 
 import (
-	"encoding/json"
 	"errors"
-	"os"
 	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-)
-
-type vectorFile struct {
-	Parse              []parseVector `json:"parse"`
-	Errors             []errorVector `json:"errors"`
-	Paths              []pathVector  `json:"paths"`
-	Generator          string        `json:"generator"`
-	VscodeURIVersion   string        `json:"vscodeURIVersion"`
-	GeneratedAt        string        `json:"generatedAt"`
-	Contract           string        `json:"contract"`
-	ReferenceGenerated []string      `json:"referenceGenerated"`
-	Curated            []string      `json:"curated"`
-	Note               string        `json:"note"`
-}
 
-type parseVector struct {
-	Name             string     `json:"name"`
-	Input            string     `json:"input"`
-	Components       Components `json:"components"`
-	String           string     `json:"string"`
-	StringNoEncoding string     `json:"stringNoEncoding"`
-	FsPathPOSIX      string     `json:"fsPathPOSIX"`
-	FsPathWindows    string     `json:"fsPathWindows"`
-}
-
-type errorVector struct {
-	Name   string `json:"name"`
-	Input  string `json:"input"`
-	Strict bool   `json:"strict"`
-	Error  string `json:"error"`
-}
+	"go.lsp.dev/uri"
+	"go.lsp.dev/uri/uritest"
+)
 
-type pathVector struct {
-	Name     string   `json:"name"`
-	Op       string   `json:"op"`
-	URI      string   `json:"uri"`
-	Segments []string `json:"segments"`
-	Want     string   `json:"want"`
-}
+// TestVectors checks the package against the conformance vectors of
+// uritest.Corpus, which embeds uritest/vectors.json. It lives in package
+// uri_test because uritest imports uri.
 
 func TestVectors(t *testing.T) {
-	vectors := readVectors(t)
+	vectors := uritest.Corpus()
 	if vectors.Generator != "vscode-uri-canonical-reparse" {
 		t.Fatalf("vectors generated by %q, want vscode-uri-canonical-reparse", vectors.Generator)
 	}
@@ -72,7 +39,7 @@ func TestVectors(t *testing.T) {
 	for _, v := range vectors.Parse {
 		t.Run("parse/"+v.Name, func(t *testing.T) {
 			t.Parallel()
-			u, err := Parse(v.Input)
+			u, err := uri.Parse(v.Input)
 			if err != nil {
 				t.Fatalf("Parse() error = %v", err)
 			}
@@ -86,12 +53,12 @@ func TestVectors(t *testing.T) {
 				t.Fatalf("StringNoEncoding() = %q, want %q", u.StringNoEncoding(), v.StringNoEncoding)
 			}
 			if v.FsPathPOSIX != "" {
-				if got := FsPathFor(u, PlatformPOSIX, false); got != v.FsPathPOSIX {
+				if got := uri.FsPathFor(u, uri.PlatformPOSIX, false); got != v.FsPathPOSIX {
 					t.Fatalf("FsPathFor(POSIX) = %q, want %q", got, v.FsPathPOSIX)
 				}
 			}
 			if v.FsPathWindows != "" {
-				if got := FsPathFor(u, PlatformWindows, false); got != v.FsPathWindows {
+				if got := uri.FsPathFor(u, uri.PlatformWindows, false); got != v.FsPathWindows {
 					t.Fatalf("FsPathFor(Windows) = %q, want %q", got, v.FsPathWindows)
 				}
 			}
@@ -103,9 +70,9 @@ func TestVectors(t *testing.T) {
 			t.Parallel()
 			var err error
 			if v.Strict {
-				_, err = ParseStrict(v.Input)
+				_, err = uri.ParseStrict(v.Input)
 			} else {
-				_, err = Parse(v.Input)
+				_, err = uri.Parse(v.Input)
 			}
 			if err == nil {
 				t.Fatal("parse succeeded, want error")
@@ -119,10 +86,10 @@ func TestVectors(t *testing.T) {
 	for _, v := range vectors.Paths {
 		t.Run("path/"+v.Name, func(t *testing.T) {
 			t.Parallel()
-			u := MustParse(v.URI)
+			u := uri.MustParse(v.URI)
 			switch v.Op {
 			case "join":
-				got, err := JoinPath(u, v.Segments...)
+				got, err := uri.JoinPath(u, v.Segments...)
 				if err != nil {
 					t.Fatalf("JoinPath() error = %v", err)
 				}
@@ -130,7 +97,7 @@ func TestVectors(t *testing.T) {
 					t.Fatalf("JoinPath() = %q, want %q", got.String(), v.Want)
 				}
 			case "resolve":
-				got, err := ResolvePath(u, v.Segments...)
+				got, err := uri.ResolvePath(u, v.Segments...)
 				if err != nil {
 					t.Fatalf("ResolvePath() error = %v", err)
 				}
@@ -138,15 +105,15 @@ func TestVectors(t *testing.T) {
 					t.Fatalf("ResolvePath() = %q, want %q", got.String(), v.Want)
 				}
 			case "dirname":
-				if got := Dirname(u).String(); got != v.Want {
+				if got := uri.Dirname(u).String(); got != v.Want {
 					t.Fatalf("Dirname() = %q, want %q", got, v.Want)
 				}
 			case "basename":
-				if got := Basename(u); got != v.Want {
+				if got := uri.Basename(u); got != v.Want {
 					t.Fatalf("Basename() = %q, want %q", got, v.Want)
 				}
 			case "extname":
-				if got := Extname(u); got != v.Want {
+				if got := uri.Extname(u); got != v.Want {
 					t.Fatalf("Extname() = %q, want %q", got, v.Want)
 				}
 			default:
@@ -154,32 +121,124 @@ func TestVectors(t *testing.T) {
 			}
 		})
 	}
-}
 
-func readVectors(t *testing.T) vectorFile {
-	t.Helper()
-	data, err := os.ReadFile("testdata/vectors.json")
-	if err != nil {
-		t.Fatal(err)
+	for _, v := range vectors.With {
+		t.Run("with/"+v.Name, func(t *testing.T) {
+			t.Parallel()
+			got, err := uri.MustParse(v.URI).With(v.Change)
+			if v.Throws {
+				if err == nil {
+					t.Fatalf("With() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("With() error = %v", err)
+			}
+			if got.String() != v.Want {
+				t.Fatalf("With() = %q, want %q", got.String(), v.Want)
+			}
+		})
 	}
-	var vectors vectorFile
-	if err := json.Unmarshal(data, &vectors); err != nil {
-		t.Fatal(err)
+
+	for _, v := range vectors.From {
+		t.Run("from/"+v.Name, func(t *testing.T) {
+			t.Parallel()
+			got, err := uri.From(v.Components)
+			if v.Throws {
+				if err == nil {
+					t.Fatalf("From() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("From() error = %v", err)
+			}
+			if got.String() != v.Want {
+				t.Fatalf("From() = %q, want %q", got.String(), v.Want)
+			}
+		})
+	}
+
+	for _, v := range vectors.File {
+		t.Run("file/"+v.Name, func(t *testing.T) {
+			t.Parallel()
+			var platform uri.Platform
+			switch v.Platform {
+			case "posix":
+				platform = uri.PlatformPOSIX
+			case "windows":
+				platform = uri.PlatformWindows
+			default:
+				t.Fatalf("unknown platform %q", v.Platform)
+			}
+			if got := uri.FileFor(platform, v.Path); got.String() != v.Want {
+				t.Fatalf("FileFor(%s) = %q, want %q", v.Platform, got.String(), v.Want)
+			}
+		})
+	}
+
+	for _, v := range vectors.NotebookCells {
+		t.Run("notebookCell/"+v.Name, func(t *testing.T) {
+			t.Parallel()
+			notebook := uri.MustParse(v.Notebook)
+			cell := uri.NotebookCell(notebook, v.Handle)
+			if cell.String() != v.Cell {
+				t.Fatalf("NotebookCell() = %q, want %q", cell.String(), v.Cell)
+			}
+			gotNotebook, gotHandle, ok := uri.ParseNotebookCell(uri.MustParse(v.Cell))
+			if !ok || gotNotebook != notebook || gotHandle != v.Handle {
+				t.Fatalf("ParseNotebookCell() = (%q, %d, %t), want (%q, %d, true)", gotNotebook.String(), gotHandle, ok, notebook.String(), v.Handle)
+			}
+		})
+	}
+
+	for _, v := range vectors.ParseOptions {
+		t.Run("parseOptions/"+v.Name, func(t *testing.T) {
+			t.Parallel()
+			p := uri.NewParser(uri.ParseOptions(v.Options))
+			got, err := p.Parse(v.Input)
+			if v.Error != "" {
+				if !errors.Is(err, sentinelForVectorError(t, v.Error)) {
+					t.Fatalf("Parser.Parse() error = %v, want %q", err, v.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parser.Parse() error = %v", err)
+			}
+			if got.String() != v.String {
+				t.Fatalf("Parser.Parse() = %q, want %q", got.String(), v.String)
+			}
+		})
 	}
-	return vectors
 }
 
 func sentinelForVectorError(t *testing.T, s string) error {
 	t.Helper()
 	switch s {
-	case ErrMissingScheme.Error():
-		return ErrMissingScheme
-	case ErrInvalidScheme.Error():
-		return ErrInvalidScheme
-	case ErrAuthorityPath.Error():
-		return ErrAuthorityPath
-	case ErrPathAuthority.Error():
-		return ErrPathAuthority
+	case uri.ErrMissingScheme.Error():
+		return uri.ErrMissingScheme
+	case uri.ErrInvalidScheme.Error():
+		return uri.ErrInvalidScheme
+	case uri.ErrAuthorityPath.Error():
+		return uri.ErrAuthorityPath
+	case uri.ErrPathAuthority.Error():
+		return uri.ErrPathAuthority
+	case uri.ErrInvalidCharacter.Error():
+		return uri.ErrInvalidCharacter
+	case uri.ErrDrivePath.Error():
+		return uri.ErrDrivePath
+	case uri.ErrUserinfo.Error():
+		return uri.ErrUserinfo
+	case uri.ErrTooLong.Error():
+		return uri.ErrTooLong
+	case uri.ErrTooComplex.Error():
+		return uri.ErrTooComplex
+	case uri.ErrControlCharacter.Error():
+		return uri.ErrControlCharacter
+	case uri.ErrSchemeNotAllowed.Error():
+		return uri.ErrSchemeNotAllowed
 	default:
 		t.Fatalf("unknown vector error %q", s)
 		return nil